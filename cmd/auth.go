@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hev/socials/internal/auth"
+	"github.com/hev/socials/internal/config"
+	"github.com/hev/socials/internal/linkedin"
+	"github.com/hev/socials/internal/output"
+	"github.com/hev/socials/internal/twitter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// loginTimeout bounds how long login waits for the browser to come
+	// back.
+	loginTimeout = 5 * time.Minute
+
+	defaultExpiryWarningDays = 7
+)
+
+var (
+	authClientID     string
+	authClientSecret string
+	authPort         int
+	authScopes       []string
+	authNoBrowser    bool
+	authKeyring      bool
+	authOAuth2       bool
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Log in to your accounts",
+	Long:  "Obtain API tokens by logging in through the browser.",
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login linkedin|twitter",
+	Short: "Log in with OAuth 2.0",
+	Long: `Log in through the browser and store the resulting tokens in the
+config, for the profile selected with --profile.
+
+The app's client ID and secret are needed on the first login and are
+remembered afterwards. Register http://localhost:<port>/callback as a
+redirect URL in the app's settings; --port defaults to 8976.
+
+Twitter logins need --oauth2: they use OAuth 2.0 with PKCE and switch the
+profile's Twitter auth_mode to oauth2. Public clients have no secret. To go
+back to OAuth 1.0a, run 'socials config set twitter.auth_mode oauth1', or
+for a named profile 'socials config set profiles.<name>.twitter.auth_mode
+oauth1'; login prints the command for the profile. tweet.read and
+users.read are always requested, to look up the account.
+
+--keyring stores the new tokens in the keyring rather than in the config
+file. Tokens that are already kept in the keyring stay there.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := profileNames()
+		if len(names) > 1 {
+			return fmt.Errorf("log in to one profile at a time")
+		}
+		var name string
+		if len(names) == 1 {
+			name = names[0]
+		}
+
+		raw, err := config.LoadRaw()
+		if errors.Is(err, config.ErrNotFound) {
+			raw, err = &config.Config{}, nil
+		}
+		if err != nil {
+			return err
+		}
+		name = raw.ResolveProfile(name)
+
+		var result *output.LoginResult
+		switch args[0] {
+		case "linkedin":
+			result, err = loginLinkedIn(raw, name)
+		case "twitter":
+			if !authOAuth2 {
+				return fmt.Errorf("twitter login needs --oauth2; OAuth 1.0a keys come from the developer portal, set them with 'socials config init'")
+			}
+			result, err = loginTwitter(raw, name)
+		default:
+			return fmt.Errorf("unknown network: %s (use 'twitter' or 'linkedin')", args[0])
+		}
+		if err != nil {
+			return err
+		}
+		return output.Print(*result, jsonOutput)
+	},
+}
+
+func loginLinkedIn(raw *config.Config, name string) (*output.LoginResult, error) {
+	p := raw.GetProfile(name)
+
+	clientID, err := flagOrSetting(authClientID, p.LinkedIn.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := flagOrSetting(authClientSecret, p.LinkedIn.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("--client-id and --client-secret are required for the first login")
+	}
+
+	scopes := authScopes
+	if len(scopes) == 0 {
+		scopes = linkedin.DefaultScopes
+	}
+
+	code, redirectURI, err := authorize(func(redirectURI, state string) string {
+		return linkedin.AuthorizationURL(clientID, redirectURI, state, scopes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := linkedin.OAuthEndpoint(clientID, clientSecret).Exchange(code, redirectURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := linkedin.NewClient(&config.LinkedInConfig{AccessToken: token.AccessToken})
+	info, err := client.GetUserInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	li := &p.LinkedIn
+	if li.AccessToken, err = config.StoreSecret(li.AccessToken, name+"/linkedin.access_token", token.AccessToken, authKeyring); err != nil {
+		return nil, err
+	}
+	if li.RefreshToken, err = config.StoreSecret(li.RefreshToken, name+"/linkedin.refresh_token", token.RefreshToken, authKeyring); err != nil {
+		return nil, err
+	}
+	if authClientID != "" {
+		li.ClientID = authClientID
+	}
+	if err := storeClientSecret(&li.ClientSecret, name+"/linkedin.client_secret"); err != nil {
+		return nil, err
+	}
+	li.ExpiresAt = auth.FormatTime(token.ExpiresAt)
+	li.RefreshTokenExpiresAt = auth.FormatTime(token.RefreshTokenExpiresAt)
+	li.Scopes = token.Scope
+	li.PersonURN = info.PersonURN()
+
+	raw.SetProfile(name, p)
+	if err := config.Save(raw); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	return &output.LoginResult{
+		Network:   "linkedin",
+		Profile:   name,
+		Account:   li.PersonURN,
+		Name:      info.Name,
+		Scopes:    token.Scope,
+		ExpiresAt: li.ExpiresAt,
+	}, nil
+}
+
+func loginTwitter(raw *config.Config, name string) (*output.LoginResult, error) {
+	p := raw.GetProfile(name)
+
+	clientID, err := flagOrSetting(authClientID, p.Twitter.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := flagOrSetting(authClientSecret, p.Twitter.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if clientID == "" {
+		return nil, fmt.Errorf("--client-id is required for the first login")
+	}
+
+	scopes := authScopes
+	if len(scopes) == 0 {
+		scopes = twitter.DefaultScopes
+	}
+	// GetMe needs these, and without it the new tokens would be lost.
+	for _, s := range []string{"tweet.read", "users.read"} {
+		if !slices.Contains(scopes, s) {
+			scopes = append(slices.Clip(scopes), s)
+		}
+	}
+
+	pkce := auth.NewPKCE()
+	code, redirectURI, err := authorize(func(redirectURI, state string) string {
+		return twitter.AuthorizationURL(clientID, redirectURI, state, pkce, scopes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := twitter.OAuthEndpoint(clientID, clientSecret).Exchange(code, redirectURI, url.Values{
+		"code_verifier": {pkce.Verifier},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tw := &p.Twitter
+	tw.AuthMode = "oauth2"
+	client := twitter.NewClient(&config.TwitterConfig{AuthMode: tw.AuthMode, OAuth2AccessToken: token.AccessToken})
+	me, err := client.GetMe()
+	if err != nil {
+		return nil, err
+	}
+
+	if tw.OAuth2AccessToken, err = config.StoreSecret(tw.OAuth2AccessToken, name+"/twitter.oauth2_access_token", token.AccessToken, authKeyring); err != nil {
+		return nil, err
+	}
+	if tw.RefreshToken, err = config.StoreSecret(tw.RefreshToken, name+"/twitter.refresh_token", token.RefreshToken, authKeyring); err != nil {
+		return nil, err
+	}
+	if authClientID != "" {
+		tw.ClientID = authClientID
+	}
+	if err := storeClientSecret(&tw.ClientSecret, name+"/twitter.client_secret"); err != nil {
+		return nil, err
+	}
+	tw.ExpiresAt = auth.FormatTime(token.ExpiresAt)
+	tw.Scopes = token.Scope
+	tw.UserID = me.ID
+
+	raw.SetProfile(name, p)
+	if err := config.Save(raw); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Twitter now uses OAuth 2.0 for profile %s; to switch back, run 'socials config set %s oauth1'\n", name, config.ProfileKey(name, "twitter.auth_mode"))
+
+	return &output.LoginResult{
+		Network:   "twitter",
+		Profile:   name,
+		Account:   "@" + me.Username,
+		Name:      me.Name,
+		Scopes:    token.Scope,
+		ExpiresAt: tw.ExpiresAt,
+	}, nil
+}
+
+// authorize sends the user to the consent page built by authURL and waits
+// for the provider to redirect back to the loopback server with a code.
+func authorize(authURL func(redirectURI, state string) string) (code, redirectURI string, err error) {
+	state := auth.RandomString(16)
+	callback, err := auth.Listen(authPort, state)
+	if err != nil {
+		return "", "", err
+	}
+	defer callback.Close()
+
+	redirectURI = callback.RedirectURI()
+	openAuthorization(authURL(redirectURI, state))
+
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+	code, err = callback.Wait(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	return code, redirectURI, nil
+}
+
+// storeClientSecret saves a --client-secret given on the command line.
+// References are kept as they are rather than copied into the keyring.
+func storeClientSecret(setting *string, account string) error {
+	switch {
+	case authClientSecret == "":
+		return nil
+	case config.IsReference(authClientSecret):
+		*setting = authClientSecret
+		return nil
+	}
+	secret, err := config.StoreSecret(*setting, account, authClientSecret, authKeyring)
+	if err != nil {
+		return err
+	}
+	*setting = secret
+	return nil
+}
+
+// tokenSavers serializes writes of refreshed tokens, which can come from
+// several clients at once under 'watch'. Separate socials processes are
+// not coordinated; the README tells users to give them separate profiles.
+var tokenSavers sync.Mutex
+
+// tokenSaver persists tokens refreshed by a client for the profile.
+func tokenSaver(profile, network string) func(*auth.Token) {
+	return func(token *auth.Token) {
+		tokenSavers.Lock()
+		defer tokenSavers.Unlock()
+
+		if err := config.SaveRefreshedToken(profile, network, token); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: refreshed %s token was not saved: %s\n", network, err)
+			return
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Refreshed %s token, valid until %s\n", network, auth.FormatTime(token.ExpiresAt))
+		}
+	}
+}
+
+// expiryWarnings describes the tokens in c that have expired or expire
+// soon and can't be refreshed.
+func expiryWarnings(c *config.Config) []string {
+	days := warningDays(c)
+
+	var warnings []string
+	for _, e := range c.TokenExpiries() {
+		if w := expiryWarning(e, days); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// expiryWarning describes e if it has expired or expires within days, and
+// can't be refreshed. It returns "" otherwise.
+func expiryWarning(e config.TokenExpiry, days int) string {
+	if e.Renewable {
+		return ""
+	}
+	left := time.Until(e.ExpiresAt)
+	date := e.ExpiresAt.Local().Format("Jan 2")
+	switch {
+	case left <= 0:
+		return fmt.Sprintf("%s %s expired on %s, run 'socials auth login %s'", e.Network, e.Token, date, e.Network)
+	case left < time.Duration(days)*24*time.Hour:
+		return fmt.Sprintf("%s %s expires in %d days (%s), run 'socials auth login %s'", e.Network, e.Token, int(left.Hours()/24), date, e.Network)
+	}
+	return ""
+}
+
+func warningDays(c *config.Config) int {
+	if c.ExpiryWarningDays <= 0 {
+		return defaultExpiryWarningDays
+	}
+	return c.ExpiryWarningDays
+}
+
+// flagOrSetting returns the flag's value if given, or else the resolved
+// config setting.
+func flagOrSetting(flag, setting string) (string, error) {
+	if flag != "" {
+		return config.ResolveSecret(flag)
+	}
+	return config.ResolveSecret(setting)
+}
+
+// openAuthorization sends the user to the provider's consent page. The URL
+// is always printed, for machines without a browser.
+func openAuthorization(url string) {
+	fmt.Fprintf(os.Stderr, "Open this URL to log in:\n\n  %s\n\n", url)
+	if authNoBrowser {
+		return
+	}
+	if err := auth.OpenBrowser(url); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open browser: %s\n", err)
+	}
+	fmt.Fprintln(os.Stderr, "Waiting for the browser to come back...")
+}
+
+func init() {
+	authLoginCmd.Flags().StringVar(&authClientID, "client-id", "", "OAuth client ID of your app")
+	authLoginCmd.Flags().StringVar(&authClientSecret, "client-secret", "", "OAuth client secret of your app (a secret reference like env:VAR works too)")
+	authLoginCmd.Flags().IntVar(&authPort, "port", 8976, "Local port for the OAuth callback")
+	authLoginCmd.Flags().StringSliceVar(&authScopes, "scopes", nil, "Scopes to request (default: everything socials uses on that network)")
+	authLoginCmd.Flags().BoolVar(&authNoBrowser, "no-browser", false, "Print the login URL instead of opening a browser")
+	authLoginCmd.Flags().BoolVar(&authKeyring, "keyring", false, "Store the tokens in the keyring")
+	authLoginCmd.Flags().BoolVar(&authOAuth2, "oauth2", false, "Use OAuth 2.0 with PKCE (required for twitter)")
+
+	authCmd.AddCommand(authLoginCmd)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/markdown"
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentsCount int
+	commentText   string
+	commentFile   string
+	commentAs     string
+)
+
+var commentsCmd = &cobra.Command{
+	Use:   "comments linkedin <post-urn>",
+	Short: "View comments on a post",
+	Long:  "List the comments on one of your LinkedIn posts.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "linkedin" {
+			return fmt.Errorf("comments are only supported for linkedin")
+		}
+
+		client, err := newLinkedInClient()
+		if err != nil {
+			return err
+		}
+
+		comments, err := client.GetComments(args[1], commentsCount)
+		if err != nil {
+			return err
+		}
+		return output.Print(comments, jsonOutput)
+	},
+}
+
+var commentCmd = &cobra.Command{
+	Use:   "comment linkedin <post-urn>",
+	Short: "Comment on a post",
+	Long: `Comment on a LinkedIn post with --text, or with the contents of a
+markdown file via --file. --as org comments as your organization page.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "linkedin" {
+			return fmt.Errorf("commenting is only supported for linkedin")
+		}
+
+		text, err := textOrFile(commentText, commentFile, markdown.ToLinkedIn)
+		if err != nil {
+			return err
+		}
+
+		client, err := newLinkedInClient()
+		if err != nil {
+			return err
+		}
+		if err := client.ActAs(commentAs); err != nil {
+			return err
+		}
+
+		result, err := client.CreateComment(args[1], text)
+		if err != nil {
+			return err
+		}
+		return output.Print(*result, jsonOutput)
+	},
+}
+
+// textOrFile returns the text given with --text as is, or the contents of
+// the markdown file given with --file passed through render.
+func textOrFile(text, file string, render func(string) string) (string, error) {
+	switch {
+	case text != "" && file != "":
+		return "", fmt.Errorf("use either --text or --file, not both")
+	case text != "":
+		return text, nil
+	case file != "":
+		content, err := markdown.ParseFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		post, err := markdown.ParsePost(content)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse file: %w", err)
+		}
+		return render(post.Body), nil
+	default:
+		return "", fmt.Errorf("--text or --file is required")
+	}
+}
+
+func init() {
+	commentsCmd.Flags().IntVarP(&commentsCount, "count", "n", 10, "Number of comments to show")
+	commentCmd.Flags().StringVarP(&commentText, "text", "t", "", "Comment text")
+	commentCmd.Flags().StringVarP(&commentFile, "file", "f", "", "Path to markdown file with the comment")
+	commentCmd.Flags().StringVar(&commentAs, "as", "", "Comment as: person (default) or org")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,7 +10,6 @@ import (
 	"github.com/hev/socials/internal/config"
 	"github.com/hev/socials/internal/output"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var configCmd = &cobra.Command{
@@ -21,28 +21,54 @@ var configCmd = &cobra.Command{
 var configInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize configuration",
-	Long:  "Set up your API tokens for Twitter and LinkedIn.",
+	Long: `Set up your API tokens for Twitter and LinkedIn. With --profile the
+accounts are stored as that named profile, leaving the others untouched.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reader := bufio.NewReader(os.Stdin)
 
+		names := profileNames()
+		if len(names) > 1 {
+			return fmt.Errorf("config init sets up one profile at a time")
+		}
+		name := config.DefaultProfile
+		if len(names) == 1 {
+			name = names[0]
+		}
+
+		// Keep the other profiles when adding one. A config that can't be
+		// read is an error rather than something to overwrite.
+		cfg, err := config.LoadRaw()
+		if errors.Is(err, config.ErrNotFound) {
+			cfg, err = &config.Config{}, nil
+		}
+		if err != nil {
+			return err
+		}
+
 		fmt.Println("Socials Configuration Setup")
 		fmt.Println("===========================")
+		if name != config.DefaultProfile {
+			fmt.Printf("Profile: %s\n", name)
+		}
 		fmt.Println()
 		fmt.Println("Twitter (leave blank to skip):")
 
-		cfg := &config.Config{}
+		var p config.Profile
 
-		cfg.Twitter.APIKey = prompt(reader, "  API Key: ")
-		cfg.Twitter.APIKeySecret = prompt(reader, "  API Key Secret: ")
-		cfg.Twitter.AccessToken = prompt(reader, "  Access Token: ")
-		cfg.Twitter.AccessTokenSecret = prompt(reader, "  Access Token Secret: ")
-		cfg.Twitter.UserID = prompt(reader, "  User ID: ")
+		p.Twitter.APIKey = prompt(reader, "  API Key: ")
+		p.Twitter.APIKeySecret = prompt(reader, "  API Key Secret: ")
+		p.Twitter.AccessToken = prompt(reader, "  Access Token: ")
+		p.Twitter.AccessTokenSecret = prompt(reader, "  Access Token Secret: ")
+		p.Twitter.UserID = prompt(reader, "  User ID: ")
 
 		fmt.Println()
 		fmt.Println("LinkedIn (leave blank to skip):")
 
-		cfg.LinkedIn.AccessToken = prompt(reader, "  Access Token: ")
-		cfg.LinkedIn.PersonURN = prompt(reader, "  Person URN (e.g. urn:li:person:abc123): ")
+		p.LinkedIn.AccessToken = prompt(reader, "  Access Token: ")
+		p.LinkedIn.PersonURN = prompt(reader, "  Person URN (e.g. urn:li:person:abc123): ")
+		p.LinkedIn.OrganizationURN = prompt(reader, "  Organization URN, to post as a page (optional): ")
+
+		cfg.SetProfile(name, p)
 
 		if err := config.Save(cfg); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
@@ -64,7 +90,10 @@ var configShowCmd = &cobra.Command{
 		}
 
 		display := output.ConfigDisplay{
+			Profile:  activeProfile(),
+			Profiles: fileCfg.ProfileNames(),
 			Twitter: output.ConfigTwitterDisplay{
+				AuthMode:          cfg.Twitter.AuthMode,
 				APIKey:            output.Redact(cfg.Twitter.APIKey),
 				APIKeySecret:      output.Redact(cfg.Twitter.APIKeySecret),
 				AccessToken:       output.Redact(cfg.Twitter.AccessToken),
@@ -72,11 +101,20 @@ var configShowCmd = &cobra.Command{
 				UserID:            cfg.Twitter.UserID,
 			},
 			LinkedIn: output.ConfigLinkedInDisplay{
-				AccessToken: output.Redact(cfg.LinkedIn.AccessToken),
-				PersonURN:   cfg.LinkedIn.PersonURN,
+				AccessToken:     output.Redact(cfg.LinkedIn.AccessToken),
+				PersonURN:       cfg.LinkedIn.PersonURN,
+				OrganizationURN: cfg.LinkedIn.OrganizationURN,
 			},
 		}
 
+		if cfg.Twitter.UsesOAuth2() {
+			display.Twitter.OAuth2Token = output.Redact(cfg.Twitter.OAuth2AccessToken)
+			display.Twitter.ExpiresAt = cfg.Twitter.ExpiresAt
+		}
+		display.LinkedIn.ExpiresAt = cfg.LinkedIn.ExpiresAt
+		display.LinkedIn.RefreshTokenExpiresAt = cfg.LinkedIn.RefreshTokenExpiresAt
+		display.Warnings = expiryWarnings(cfg)
+
 		return output.Print(display, jsonOutput)
 	},
 }
@@ -86,33 +124,96 @@ var configSetCmd = &cobra.Command{
 	Short: "Set a configuration value",
 	Long: `Set a configuration value. Keys use dot notation.
 
+Values can reference a secret instead of holding it: env:VAR reads an
+environment variable, file:PATH a file and cmd:COMMAND the output of a
+command. Every key can also be overridden with a SOCIALS_* environment
+variable, e.g. SOCIALS_TWITTER_API_KEY for twitter.api_key.
+
 Examples:
   socials config set twitter.api_key YOUR_KEY
   socials config set linkedin.access_token YOUR_TOKEN
-  socials config set twitter.user_id 12345`,
+  socials config set linkedin.organization_urn urn:li:organization:123
+  socials config set twitter.user_id 12345
+  socials config set profiles.brand.twitter.user_id 67890`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := args[1]
 
-		// Load existing config or create new
-		existingCfg, err := config.Load()
-		if err != nil {
-			existingCfg = &config.Config{}
+		if err := config.Set(key, value); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		viper.Set(key, value)
+		fmt.Printf("Set %s\n", key)
+		return nil
+	},
+}
+
+var configUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Set the default profile",
+	Long: `Set the profile used when neither --profile nor SOCIALS_PROFILE picks
+one. "default" is the accounts at the top level of the config file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
 
-		// Re-unmarshal to get updated config
-		if err := viper.Unmarshal(existingCfg); err != nil {
-			return fmt.Errorf("failed to update config: %w", err)
+		existingCfg, err := config.LoadRaw()
+		if err != nil {
+			return err
+		}
+		if !existingCfg.HasProfile(name) {
+			return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(existingCfg.ProfileNames(), ", "))
 		}
 
+		existingCfg.DefaultProfile = name
 		if err := config.Save(existingCfg); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		fmt.Printf("Set %s\n", key)
+		fmt.Printf("Default profile is now %s\n", name)
+		return nil
+	},
+}
+
+var configMigrateSecretsCmd = &cobra.Command{
+	Use:   "migrate-secrets",
+	Short: "Move tokens from the config file to the keyring",
+	Long: `Move every token stored in plain text in config.yaml, in all profiles,
+to the system keyring and leave keyring: references in its place.
+
+Without a keyring service (e.g. in CI), set SOCIALS_KEYRING_PASSPHRASE to
+use an encrypted secrets.enc file in the config dir instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		existingCfg, err := config.LoadRaw()
+		if err != nil {
+			return err
+		}
+		backend, err := config.KeyringBackend()
+		if err != nil {
+			return err
+		}
+
+		moved, err := config.MigrateSecrets(existingCfg)
+		// Save whatever was moved, so the file never points at secrets
+		// that only exist in plain text.
+		if len(moved) > 0 {
+			if saveErr := config.Save(existingCfg); saveErr != nil {
+				return fmt.Errorf("failed to save config: %w", saveErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(moved) == 0 {
+			fmt.Println("No plain-text secrets left in the config file")
+			return nil
+		}
+		for _, key := range moved {
+			fmt.Printf("Moved %s to the %s keyring\n", key, backend)
+		}
 		return nil
 	},
 }
@@ -121,6 +222,8 @@ func init() {
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUseCmd)
+	configCmd.AddCommand(configMigrateSecretsCmd)
 }
 
 func prompt(reader *bufio.Reader, label string) string {
@@ -128,3 +231,15 @@ func prompt(reader *bufio.Reader, label string) string {
 	text, _ := reader.ReadString('\n')
 	return strings.TrimSpace(text)
 }
+
+// confirm asks a yes/no question on stderr so it doesn't mix with --json
+// output on stdout.
+func confirm(label string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", label)
+	text, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/hev/socials/internal/history"
+	"github.com/hev/socials/internal/output"
+	"github.com/hev/socials/internal/twitter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteThread bool
+	deleteYes    bool
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete [twitter|linkedin] <id>",
+	Short: "Delete a post",
+	Long: `Delete a tweet by ID or a LinkedIn post by URN.
+
+With --thread, every part of a Twitter thread you posted is deleted, newest
+first. Threads are found in local post history, falling back to searching
+the tweet's conversation (recent search only covers the last seven days).`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		network := args[0]
+		id := args[1]
+
+		switch network {
+		case "twitter":
+			client, err := newTwitterClient()
+			if err != nil {
+				return err
+			}
+
+			ids := []string{id}
+			if deleteThread {
+				ids, err = twitterThreadIDs(client, id)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !deleteYes && !confirm(fmt.Sprintf("Delete %d tweet(s) from twitter?", len(ids))) {
+				return fmt.Errorf("aborted")
+			}
+
+			var results []output.DeleteResult
+			var deleted []string
+			for _, tweetID := range slices.Backward(ids) {
+				result, err := client.DeleteTweet(tweetID)
+				if err != nil {
+					forgetDeleted("twitter", deleted)
+					return err
+				}
+				results = append(results, *result)
+				deleted = append(deleted, tweetID)
+			}
+			forgetDeleted("twitter", deleted)
+			return output.Print(results, jsonOutput)
+
+		case "linkedin":
+			if deleteThread {
+				return fmt.Errorf("--thread is only supported for twitter")
+			}
+			client, err := newLinkedInClient()
+			if err != nil {
+				return err
+			}
+
+			if !deleteYes && !confirm(fmt.Sprintf("Delete %s from linkedin?", id)) {
+				return fmt.Errorf("aborted")
+			}
+
+			result, err := client.DeletePost(id)
+			if err != nil {
+				return err
+			}
+			return output.Print(*result, jsonOutput)
+
+		default:
+			return fmt.Errorf("unknown network: %s (use 'twitter' or 'linkedin')", network)
+		}
+	},
+}
+
+// twitterThreadIDs returns the IDs of the thread containing id, oldest
+// first, preferring local history over the API.
+func twitterThreadIDs(client *twitter.Client, id string) ([]string, error) {
+	entry, err := history.FindThread("twitter", id)
+	if err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+	}
+	if entry != nil {
+		return entry.IDs, nil
+	}
+
+	ids, err := client.ConversationThread(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find thread: %w", err)
+	}
+	return ids, nil
+}
+
+func forgetDeleted(network string, ids []string) {
+	if err := history.Forget(network, ids); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+	}
+}
+
+func init() {
+	deleteCmd.Flags().BoolVar(&deleteThread, "thread", false, "Delete every part of the thread containing this tweet")
+	deleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip the confirmation prompt")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/markdown"
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dmText string
+	dmFile string
+
+	dmConversation bool
+)
+
+var dmCmd = &cobra.Command{
+	Use:   "dm",
+	Short: "Send direct messages",
+	Long:  "Send direct messages on Twitter or LinkedIn. Use 'socials messages' to read them.",
+}
+
+var dmSendCmd = &cobra.Command{
+	Use:   "send [twitter|linkedin] <recipient>",
+	Short: "Send a direct message",
+	Long: `Send a direct message with --text, which is sent exactly as typed, or
+with a markdown file via --file (rendered as plain text).
+
+On Twitter the recipient is an @handle or a user ID. On LinkedIn it is a
+member URN (urn:li:person:...) or a conversation ID.
+
+With --conversation the recipient is a conversation ID, as listed by
+'socials messages', which is how to write to group conversations.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		network := args[0]
+		to := args[1]
+
+		text, err := textOrFile(dmText, dmFile, markdown.ToPlainText)
+		if err != nil {
+			return err
+		}
+
+		switch network {
+		case "twitter":
+			client, err := newTwitterClient()
+			if err != nil {
+				return err
+			}
+			send := client.SendDirectMessage
+			if dmConversation {
+				send = client.SendToConversation
+			}
+			sent, err := send(to, text)
+			if err != nil {
+				return err
+			}
+			return output.Print(*sent, jsonOutput)
+
+		case "linkedin":
+			client, err := newLinkedInClient()
+			if err != nil {
+				return err
+			}
+			sent, err := client.SendMessage(to, text, dmConversation)
+			if err != nil {
+				return err
+			}
+			return output.Print(*sent, jsonOutput)
+
+		default:
+			return fmt.Errorf("unknown network: %s (use 'twitter' or 'linkedin')", network)
+		}
+	},
+}
+
+func init() {
+	dmSendCmd.Flags().StringVarP(&dmText, "text", "t", "", "Message text, sent as is")
+	dmSendCmd.Flags().StringVarP(&dmFile, "file", "f", "", "Path to markdown file with the message")
+	dmSendCmd.Flags().BoolVar(&dmConversation, "conversation", false, "The recipient is a conversation ID")
+	dmCmd.AddCommand(dmSendCmd)
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hev/socials/internal/linkedin"
+	"github.com/hev/socials/internal/output"
+	"github.com/hev/socials/internal/twitter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// maxClockSkew is roughly where Twitter starts rejecting OAuth 1.0a
+	// timestamps; past it, expiry checks are off too.
+	maxClockSkew = 5 * time.Minute
+	// clockSkewWarning flags clocks worth fixing before they break.
+	clockSkewWarning = 30 * time.Second
+)
+
+// scopeNeed is a set of scopes the listed commands need.
+type scopeNeed struct {
+	scopes   []string
+	commands string
+}
+
+var twitterScopeNeeds = []scopeNeed{
+	{[]string{"tweet.read", "users.read"}, "feed, mentions, search, thread, user"},
+	{[]string{"tweet.write"}, "post, reply, quote, retweet, delete"},
+	{[]string{"like.write"}, "like, unlike"},
+	{[]string{"dm.read"}, "messages"},
+	{[]string{"dm.write"}, "dm"},
+	{[]string{"offline.access"}, "automatic token refresh"},
+}
+
+var linkedInScopeNeeds = []scopeNeed{
+	{[]string{"openid", "profile"}, "doctor, auth login"},
+	{[]string{"w_member_social"}, "post, comment, edit, delete"},
+	{[]string{"r_member_social"}, "feed, comments"},
+}
+
+// twitterAccessNeeds maps OAuth 1.0a app permissions, as found in the
+// access level, to the commands that need them.
+var twitterAccessNeeds = []struct {
+	permission string
+	commands   string
+}{
+	{"write", "post, reply, quote, retweet, like, delete"},
+	{"directmessages", "messages, dm"},
+}
+
+var doctorStrict bool
+
+var doctorCmd = &cobra.Command{
+	Use:     "doctor [twitter|linkedin]",
+	Aliases: []string{"whoami"},
+	Short:   "Verify credentials against the APIs",
+	Long: `Check that each configured account's tokens work: who they belong to,
+whether that matches the configured user_id or person_urn, whether the
+granted scopes cover every command, and whether the local clock agrees
+with the API's (OAuth 1.0a signatures fail on skewed clocks).
+
+Scopes are known for tokens from 'socials auth login'; for Twitter OAuth 1.0a
+keys the app's access level is checked instead.
+
+Exits non-zero if any check fails, so it can gate CI jobs; --json gives a
+machine-readable report.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		networks := []string{"twitter", "linkedin"}
+		if len(args) == 1 {
+			if args[0] != "twitter" && args[0] != "linkedin" {
+				return fmt.Errorf("unknown network: %s (use 'twitter' or 'linkedin')", args[0])
+			}
+			networks = args
+		}
+
+		report := output.DoctorReport{Profile: activeProfile()}
+		for _, network := range networks {
+			d := &doctor{network: network}
+			switch {
+			case cfg == nil:
+				d.add("config", "fail", "config not found, run 'socials config init' first")
+			case network == "twitter":
+				d.checkTwitter(len(args) == 1)
+			case network == "linkedin":
+				d.checkLinkedIn(len(args) == 1)
+			}
+			report.Checks = append(report.Checks, d.checks...)
+		}
+
+		report.OK = true
+		for _, c := range report.Checks {
+			if c.Status == "fail" || (doctorStrict && c.Status == "warn") {
+				report.OK = false
+			}
+		}
+
+		if err := output.Print(report, jsonOutput); err != nil {
+			return err
+		}
+		if !report.OK {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("credential checks failed")
+		}
+		return nil
+	},
+}
+
+// doctor collects the checks for one network.
+type doctor struct {
+	network string
+	checks  []output.DoctorCheck
+}
+
+func (d *doctor) add(check, status, format string, args ...any) {
+	d.checks = append(d.checks, output.DoctorCheck{
+		Network: d.network,
+		Check:   check,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkTwitter verifies the Twitter tokens. An unconfigured network only
+// fails when it was asked for explicitly.
+func (d *doctor) checkTwitter(required bool) {
+	if !cfg.HasTwitter() {
+		d.notConfigured(required)
+		return
+	}
+	if err := cfg.Twitter.Resolve(); err != nil {
+		d.add("config", "fail", "%s", err)
+		return
+	}
+	oauth2 := cfg.Twitter.UsesOAuth2()
+	if oauth2 {
+		d.add("config", "ok", "OAuth 2.0 user token")
+	} else {
+		d.add("config", "ok", "OAuth 1.0a keys")
+	}
+
+	client := twitter.NewClient(&cfg.Twitter)
+	me, err := client.GetMe()
+	switch {
+	case err != nil:
+		d.add("identity", "fail", "%s", err)
+	case cfg.Twitter.UserID == "":
+		d.add("identity", "warn", "token belongs to @%s (%s), but user_id is not set; run 'socials config set twitter.user_id %s'", me.Username, me.ID, me.ID)
+	case cfg.Twitter.UserID != me.ID:
+		d.add("identity", "fail", "token belongs to @%s (%s), but user_id is %s", me.Username, me.ID, cfg.Twitter.UserID)
+	default:
+		d.add("identity", "ok", "@%s (%s)", me.Username, me.ID)
+	}
+
+	if oauth2 {
+		d.checkScopes(cfg.Twitter.Scopes, twitterScopeNeeds)
+	} else {
+		d.checkAccessLevel(client.AccessLevel())
+	}
+	d.checkExpiry(client.TokenExpiry())
+
+	skew, ok := client.ClockSkew()
+	d.checkClock(skew, ok, !oauth2)
+}
+
+func (d *doctor) checkLinkedIn(required bool) {
+	if !cfg.HasLinkedIn() {
+		d.notConfigured(required)
+		return
+	}
+	if err := cfg.LinkedIn.Resolve(); err != nil {
+		d.add("config", "fail", "%s", err)
+		return
+	}
+	d.add("config", "ok", "access token")
+
+	client := linkedin.NewClient(&cfg.LinkedIn)
+	info, err := client.GetUserInfo()
+	switch {
+	case err != nil:
+		d.add("identity", "fail", "%s", err)
+	case cfg.LinkedIn.PersonURN != info.PersonURN():
+		d.add("identity", "fail", "token belongs to %s (%s), but person_urn is %s", info.Name, info.PersonURN(), cfg.LinkedIn.PersonURN)
+	default:
+		d.add("identity", "ok", "%s (%s)", info.Name, info.PersonURN())
+	}
+
+	needs := linkedInScopeNeeds
+	if cfg.LinkedIn.OrganizationURN != "" {
+		needs = append(needs[:len(needs):len(needs)], scopeNeed{[]string{"w_organization_social"}, "posting with --as org"})
+	}
+	d.checkScopes(cfg.LinkedIn.Scopes, needs)
+	d.checkExpiry(client.TokenExpiry())
+
+	skew, ok := client.ClockSkew()
+	d.checkClock(skew, ok, false)
+}
+
+func (d *doctor) notConfigured(required bool) {
+	status := "skip"
+	if required {
+		status = "fail"
+	}
+	d.add("config", status, "%s not configured, run 'socials config init'", d.network)
+}
+
+// checkScopes compares granted scopes, which providers separate with
+// spaces or commas, against needs.
+func (d *doctor) checkScopes(granted string, needs []scopeNeed) {
+	if granted == "" {
+		d.add("scopes", "skip", "granted scopes unknown; they are recorded by 'socials auth login %s'", d.network)
+		return
+	}
+	have := make(map[string]bool)
+	for _, s := range strings.FieldsFunc(granted, func(r rune) bool { return r == ' ' || r == ',' }) {
+		have[s] = true
+	}
+
+	complete := true
+	for _, need := range needs {
+		var missing []string
+		for _, s := range need.scopes {
+			if !have[s] {
+				missing = append(missing, s)
+			}
+		}
+		if len(missing) > 0 {
+			complete = false
+			d.add("scopes", "warn", "missing %s, needed for %s", strings.Join(missing, ", "), need.commands)
+		}
+	}
+	if complete {
+		d.add("scopes", "ok", "%s", granted)
+	}
+}
+
+func (d *doctor) checkAccessLevel(level string) {
+	if level == "" {
+		d.add("scopes", "skip", "access level unknown, the API didn't report it")
+		return
+	}
+
+	complete := true
+	for _, need := range twitterAccessNeeds {
+		if !strings.Contains(level, need.permission) {
+			complete = false
+			d.add("scopes", "warn", "access level %s lacks %s permission, needed for %s; change the app's permissions and regenerate the access token", level, need.permission, need.commands)
+		}
+	}
+	if complete {
+		d.add("scopes", "ok", "access level %s", level)
+	}
+}
+
+// checkExpiry reports the network's token expiries. current is the access
+// token's expiry according to the client, which is newer than the config's
+// if the identity check refreshed the token.
+func (d *doctor) checkExpiry(current time.Time) {
+	days := warningDays(cfg)
+	for _, e := range cfg.TokenExpiries() {
+		if e.Network != d.network {
+			continue
+		}
+		if e.Token == "access token" && !current.IsZero() {
+			e.ExpiresAt = current
+		}
+		until := e.ExpiresAt.Local().Format("Jan 2 15:04")
+		switch w := expiryWarning(e, days); {
+		case w != "":
+			d.add("expiry", "warn", "%s", w)
+		case time.Now().After(e.ExpiresAt):
+			// The identity check would have refreshed it.
+			d.add("expiry", "warn", "%s expired %s and couldn't be refreshed", e.Token, until)
+		default:
+			d.add("expiry", "ok", "%s valid until %s", e.Token, until)
+		}
+	}
+}
+
+// checkClock reports the skew between the local clock and the API's.
+// signed is set for OAuth 1.0a, where a large skew breaks every request.
+func (d *doctor) checkClock(skew time.Duration, ok, signed bool) {
+	if !ok {
+		d.add("clock", "skip", "no response to compare clocks with")
+		return
+	}
+
+	abs := skew.Abs()
+	direction := "behind"
+	if skew < 0 {
+		direction = "ahead of"
+	}
+	msg := fmt.Sprintf("local clock is %s %s the API's", abs.Round(time.Second), direction)
+	switch {
+	case abs > maxClockSkew && signed:
+		d.add("clock", "fail", "%s; OAuth 1.0a requests are rejected, sync the clock", msg)
+	case abs > clockSkewWarning:
+		d.add("clock", "warn", "%s; sync the clock", msg)
+	default:
+		d.add("clock", "ok", "%s", msg)
+	}
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorStrict, "strict", false, "Treat warnings as failures")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/markdown"
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editFile   string
+	editDryRun bool
+	editYes    bool
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit linkedin <urn>",
+	Short: "Edit a published post",
+	Long: `Replace the text of a published LinkedIn post with the contents of a
+markdown file. The old and new commentary are diffed before the update is
+applied.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		network := args[0]
+		urn := args[1]
+
+		if network != "linkedin" {
+			return fmt.Errorf("editing is only supported for linkedin")
+		}
+		if editFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+
+		content, err := markdown.ParseFile(editFile)
+		if err != nil {
+			return fmt.Errorf("failed to read post file: %w", err)
+		}
+		post, err := markdown.ParsePost(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse post file: %w", err)
+		}
+
+		client, err := newLinkedInClient()
+		if err != nil {
+			return err
+		}
+
+		oldText, err := client.GetCommentary(urn)
+		if err != nil {
+			return err
+		}
+		newText := markdown.ToLinkedIn(post.Body)
+
+		result := output.EditResult{
+			Network: "linkedin",
+			ID:      urn,
+			OldText: oldText,
+			NewText: newText,
+			Diff:    output.LineDiff(oldText, newText),
+		}
+
+		if oldText == newText {
+			return fmt.Errorf("no changes: post already matches %s", editFile)
+		}
+		if editDryRun {
+			return output.Print(result, jsonOutput)
+		}
+
+		if !editYes {
+			if !jsonOutput {
+				output.PrintDiff(result.Diff)
+			}
+			if !confirm("Apply this change?") {
+				return fmt.Errorf("aborted")
+			}
+		}
+
+		if err := client.UpdateCommentary(urn, newText); err != nil {
+			return err
+		}
+		result.Applied = true
+
+		return output.Print(result, jsonOutput)
+	},
+}
+
+func init() {
+	editCmd.Flags().StringVarP(&editFile, "file", "f", "", "Path to markdown file with the new post text")
+	editCmd.Flags().BoolVar(&editDryRun, "dry-run", false, "Show the diff without applying it")
+	editCmd.Flags().BoolVarP(&editYes, "yes", "y", false, "Skip the confirmation prompt")
+}
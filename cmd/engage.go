@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/markdown"
+	"github.com/hev/socials/internal/output"
+	"github.com/hev/socials/internal/twitter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replyFile   string
+	replyDryRun bool
+	quoteFile   string
+	quoteDryRun bool
+)
+
+var replyCmd = &cobra.Command{
+	Use:   "reply twitter <tweet-id>",
+	Short: "Reply to a tweet",
+	Long: `Reply to a tweet with the contents of a markdown file.
+Long replies are split into a thread under the original tweet.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "twitter" {
+			return fmt.Errorf("replying is only supported for twitter")
+		}
+		return postRelated(twitter.ThreadOptions{InReplyTo: args[1]}, replyFile, replyDryRun)
+	},
+}
+
+var quoteCmd = &cobra.Command{
+	Use:   "quote <tweet-id>",
+	Short: "Quote a tweet",
+	Long: `Quote a tweet with the contents of a markdown file.
+Long quotes are split into a thread starting with the quote tweet.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return postRelated(twitter.ThreadOptions{Quote: args[0]}, quoteFile, quoteDryRun)
+	},
+}
+
+// postRelated posts a markdown file as a thread attached to an existing
+// tweet as described by opts (reply or quote).
+func postRelated(opts twitter.ThreadOptions, file string, dryRun bool) error {
+	if file == "" {
+		return fmt.Errorf("--file is required")
+	}
+
+	content, err := markdown.ParseFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read post file: %w", err)
+	}
+	post, err := markdown.ParsePost(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse post file: %w", err)
+	}
+
+	chunks, poll, err := renderTwitter(post)
+	if err != nil {
+		return err
+	}
+	if opts, err = withPoll(opts, chunks, poll); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return doDryRun(post, []string{"twitter"})
+	}
+
+	client, err := newTwitterClient()
+	if err != nil {
+		return err
+	}
+
+	results, err := client.PostThreadWith(chunks, opts)
+	if len(results) > 1 {
+		recordThread("twitter", results)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to post to twitter: %w", err)
+	}
+
+	return output.Print(results, jsonOutput)
+}
+
+func engageCommand(use, short string, action func(*twitter.Client, string) (*output.EngagementResult, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <tweet-id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newTwitterClient()
+			if err != nil {
+				return err
+			}
+
+			result, err := action(client, args[0])
+			if err != nil {
+				return err
+			}
+			return output.Print(*result, jsonOutput)
+		},
+	}
+}
+
+var (
+	retweetCmd   = engageCommand("retweet", "Retweet a tweet", (*twitter.Client).Retweet)
+	unretweetCmd = engageCommand("unretweet", "Undo a retweet", (*twitter.Client).Unretweet)
+	likeCmd      = engageCommand("like", "Like a tweet", (*twitter.Client).Like)
+	unlikeCmd    = engageCommand("unlike", "Remove a like from a tweet", (*twitter.Client).Unlike)
+)
+
+func init() {
+	replyCmd.Flags().StringVarP(&replyFile, "file", "f", "", "Path to markdown file with the reply")
+	replyCmd.Flags().BoolVar(&replyDryRun, "dry-run", false, "Preview the reply without publishing")
+	quoteCmd.Flags().StringVarP(&quoteFile, "file", "f", "", "Path to markdown file with the quote text")
+	quoteCmd.Flags().BoolVar(&quoteDryRun, "dry-run", false, "Preview the quote without publishing")
+}
@@ -2,49 +2,165 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/hev/socials/internal/linkedin"
 	"github.com/hev/socials/internal/output"
-	"github.com/hev/socials/internal/twitter"
+	"github.com/hev/socials/internal/state"
 	"github.com/spf13/cobra"
 )
 
-var feedCount int
+var (
+	feedPage pageFlags
+	feedAs   string
+	feedNew  bool
+	feedUser string
+)
 
 var feedCmd = &cobra.Command{
 	Use:   "feed [twitter|linkedin]",
 	Short: "View your feed",
-	Long:  "View your home timeline (Twitter) or your posts (LinkedIn).",
-	Args:  cobra.ExactArgs(1),
+	Long: `View your home timeline (Twitter) or your posts (LinkedIn).
+Use --user to read someone else's tweets instead, or --as org to list your
+LinkedIn organization page's posts.
+
+Results are paged: --json output is an object with the items under "tweets"
+or "posts" and a next_cursor, which --cursor takes to continue where the
+previous page ended, also when --limit cut a page short. --limit and --all
+fetch several pages in one go.
+
+--new returns only items newer than the last --new run for this network and
+saves a checkpoint once they have been printed, for agents that poll.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		network := args[0]
 
 		if cfg == nil {
 			return fmt.Errorf("config not found, run 'socials config init' first")
 		}
+		// --limit keeps the newest items, so checkpointing them would skip
+		// the older ones it cut.
+		if feedNew && (feedPage.since != "" || feedPage.cursor != "" || feedPage.limit > 0) {
+			return fmt.Errorf("--new can't be combined with --since, --cursor or --limit")
+		}
+		// Catching up after a checkpoint can span many pages, so default to
+		// the largest page size.
+		if feedNew && !cmd.Flags().Changed("count") {
+			feedPage.count = 100
+		}
 
 		switch network {
 		case "twitter":
-			if !cfg.HasTwitter() {
-				return fmt.Errorf("twitter not configured, run 'socials config init'")
+			client, err := newTwitterClient()
+			if err != nil {
+				return err
+			}
+			opts, err := feedPage.twitter()
+			if err != nil {
+				return err
+			}
+
+			// Someone else's timeline gets its own checkpoint.
+			feedName := "twitter"
+			var userID string
+			if feedUser != "" {
+				if userID, err = client.ResolveUserID(feedUser); err != nil {
+					return err
+				}
+				feedName = "twitter-user-" + userID
+			}
+
+			key := state.CheckpointKey(activeProfile(), feedName)
+			var cp state.Checkpoint
+			if feedNew {
+				if cp, err = state.LoadCheckpoint(key); err != nil {
+					return err
+				}
+				if cp.SinceID != "" {
+					opts.SinceID = cp.SinceID
+					opts.All = true
+				}
+			}
+
+			var tweets *output.TweetPage
+			if userID != "" {
+				tweets, err = client.GetUserTweets(userID, opts)
+			} else {
+				tweets, err = client.GetTimeline(opts)
 			}
-			client := twitter.NewClient(&cfg.Twitter)
-			tweets, err := client.GetTimeline(feedCount)
 			if err != nil {
 				return err
 			}
-			return output.Print(tweets, jsonOutput)
+			if err := output.Print(*tweets, jsonOutput); err != nil {
+				return err
+			}
+
+			if feedNew && len(tweets.Tweets) > 0 {
+				for _, t := range tweets.Tweets {
+					cp.SeeID(t.ID)
+				}
+				return state.SaveCheckpoint(key, cp)
+			}
+			return nil
 
 		case "linkedin":
-			if !cfg.HasLinkedIn() {
-				return fmt.Errorf("linkedin not configured, run 'socials config init'")
+			if feedUser != "" {
+				return fmt.Errorf("--user is only supported for twitter")
+			}
+			client, err := newLinkedInClient()
+			if err != nil {
+				return err
+			}
+			opts, err := feedPage.linkedin()
+			if err != nil {
+				return err
+			}
+			if err := client.ActAs(feedAs); err != nil {
+				return err
+			}
+			// Personal and organization feeds are checkpointed separately.
+			feedName := "linkedin"
+			if linkedin.IsOrganizationAuthor(feedAs) {
+				feedName = "linkedin-org"
+			}
+			key := state.CheckpointKey(activeProfile(), feedName)
+			var cp state.Checkpoint
+			if feedNew {
+				if cp, err = state.LoadCheckpoint(key); err != nil {
+					return err
+				}
+				// Posts from the checkpoint's second come back too and are
+				// told apart by ID.
+				if since := cp.SinceTime(); !since.IsZero() {
+					opts.Since = since
+					opts.All = true
+				}
 			}
-			client := linkedin.NewClient(&cfg.LinkedIn)
-			posts, err := client.GetPosts(feedCount)
+
+			posts, err := client.GetPosts(opts)
 			if err != nil {
 				return err
 			}
-			return output.Print(posts, jsonOutput)
+			if feedNew {
+				posts.Posts = slices.DeleteFunc(posts.Posts, func(p output.LinkedInPost) bool {
+					created, err := time.Parse(time.RFC3339, p.CreatedAt)
+					return err == nil && cp.Seen(created, p.ID)
+				})
+			}
+			if err := output.Print(*posts, jsonOutput); err != nil {
+				return err
+			}
+
+			if feedNew && len(posts.Posts) > 0 {
+				for _, p := range posts.Posts {
+					if created, err := time.Parse(time.RFC3339, p.CreatedAt); err == nil {
+						cp.SeeTime(created, p.ID)
+					}
+				}
+				return state.SaveCheckpoint(key, cp)
+			}
+			return nil
 
 		default:
 			return fmt.Errorf("unknown network: %s (use 'twitter' or 'linkedin')", network)
@@ -53,5 +169,8 @@ var feedCmd = &cobra.Command{
 }
 
 func init() {
-	feedCmd.Flags().IntVarP(&feedCount, "count", "n", 10, "Number of items to show")
+	addPageFlags(feedCmd, &feedPage, "items")
+	feedCmd.Flags().StringVar(&feedAs, "as", "", "LinkedIn author whose posts to list: person (default) or org")
+	feedCmd.Flags().StringVar(&feedUser, "user", "", "Show this Twitter user's tweets (@handle or ID) instead of your timeline")
+	feedCmd.Flags().BoolVar(&feedNew, "new", false, "Only show items newer than the saved checkpoint, then advance it")
 }
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hev/socials/internal/markdown"
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var lintNetwork string
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <file>...",
+	Short: "Check post files without posting",
+	Long: `Check that markdown post files would be accepted: front matter,
+polls (2-4 options of at most 25 characters), LinkedIn visibility and
+article settings. Nothing is sent and no config is needed, so it can run
+in CI.
+
+Exits non-zero if any file has a problem.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		networks := strings.Split(lintNetwork, ",")
+		for i := range networks {
+			networks[i] = strings.TrimSpace(networks[i])
+			if networks[i] != "twitter" && networks[i] != "linkedin" {
+				return fmt.Errorf("unknown network: %s", networks[i])
+			}
+		}
+
+		var results []output.LintResult
+		ok := true
+		for _, file := range args {
+			for _, network := range networks {
+				result := output.LintResult{File: file, Network: network, OK: true}
+				if err := lintFile(file, network); err != nil {
+					result.OK = false
+					result.Error = err.Error()
+					ok = false
+				}
+				results = append(results, result)
+			}
+		}
+
+		if err := output.Print(results, jsonOutput); err != nil {
+			return err
+		}
+		if !ok {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("lint found problems")
+		}
+		return nil
+	},
+}
+
+// lintFile renders file for network the way post does, returning the
+// first problem that would stop it from being posted.
+func lintFile(file, network string) error {
+	content, err := markdown.ParseFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read post file: %w", err)
+	}
+	post, err := markdown.ParsePost(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse post file: %w", err)
+	}
+
+	switch network {
+	case "twitter":
+		_, _, err = renderTwitter(post)
+	case "linkedin":
+		_, err = linkedinOptions(post, filepath.Dir(file))
+	}
+	return err
+}
+
+func init() {
+	lintCmd.Flags().StringVarP(&lintNetwork, "network", "n", "twitter,linkedin", "Networks to check for (comma-separated: twitter,linkedin)")
+}
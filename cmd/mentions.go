@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var mentionsPage pageFlags
+
+var mentionsCmd = &cobra.Command{
+	Use:   "mentions twitter",
+	Short: "View tweets mentioning you",
+	Long: `View recent tweets that mention you, newest first. Each tweet includes
+the tweet it replies to and its conversation ID, for following up with
+'socials reply'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "twitter" {
+			return fmt.Errorf("mentions are only supported for twitter")
+		}
+
+		opts, err := mentionsPage.twitter()
+		if err != nil {
+			return err
+		}
+		client, err := newTwitterClient()
+		if err != nil {
+			return err
+		}
+
+		mentions, err := client.GetMentions(opts)
+		if err != nil {
+			return err
+		}
+		return output.Print(*mentions, jsonOutput)
+	},
+}
+
+func init() {
+	addPageFlags(mentionsCmd, &mentionsPage, "mentions")
+}
@@ -3,32 +3,54 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/hev/socials/internal/linkedin"
 	"github.com/hev/socials/internal/output"
-	"github.com/hev/socials/internal/twitter"
 	"github.com/spf13/cobra"
 )
 
-var messagesCount int
+var (
+	messagesCount          int
+	messagesByConversation bool
+)
 
 var messagesCmd = &cobra.Command{
-	Use:   "messages [twitter|linkedin]",
+	Use:   "messages [twitter|linkedin] [conversation-id]",
 	Short: "View your direct messages",
-	Long:  "View your recent direct messages on Twitter or LinkedIn.",
-	Args:  cobra.ExactArgs(1),
+	Long: `View your recent direct messages on Twitter or LinkedIn.
+
+--by-conversation groups them into conversations with their participants
+and a preview of the latest message. Pass a conversation ID to read that
+whole thread, oldest message first.
+
+--count is the number of messages shown, or of conversations with
+--by-conversation, on both networks.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		network := args[0]
-
-		if cfg == nil {
-			return fmt.Errorf("config not found, run 'socials config init' first")
+		var conversationID string
+		if len(args) == 2 {
+			conversationID = args[1]
 		}
 
 		switch network {
 		case "twitter":
-			if !cfg.HasTwitter() {
-				return fmt.Errorf("twitter not configured, run 'socials config init'")
+			client, err := newTwitterClient()
+			if err != nil {
+				return err
+			}
+			switch {
+			case conversationID != "":
+				conv, err := client.GetDMConversation(conversationID, messagesCount)
+				if err != nil {
+					return err
+				}
+				return output.Print(*conv, jsonOutput)
+			case messagesByConversation:
+				conversations, err := client.GetDMConversations(messagesCount)
+				if err != nil {
+					return err
+				}
+				return output.Print(conversations, jsonOutput)
 			}
-			client := twitter.NewClient(&cfg.Twitter)
 			messages, err := client.GetDirectMessages(messagesCount)
 			if err != nil {
 				return err
@@ -36,10 +58,24 @@ var messagesCmd = &cobra.Command{
 			return output.Print(messages, jsonOutput)
 
 		case "linkedin":
-			if !cfg.HasLinkedIn() {
-				return fmt.Errorf("linkedin not configured, run 'socials config init'")
+			client, err := newLinkedInClient()
+			if err != nil {
+				return err
+			}
+			switch {
+			case conversationID != "":
+				conv, err := client.GetConversation(conversationID, messagesCount)
+				if err != nil {
+					return err
+				}
+				return output.Print(*conv, jsonOutput)
+			case messagesByConversation:
+				conversations, err := client.GetConversations(messagesCount)
+				if err != nil {
+					return err
+				}
+				return output.Print(conversations, jsonOutput)
 			}
-			client := linkedin.NewClient(&cfg.LinkedIn)
 			messages, err := client.GetMessages(messagesCount)
 			if err != nil {
 				return err
@@ -53,5 +89,6 @@ var messagesCmd = &cobra.Command{
 }
 
 func init() {
-	messagesCmd.Flags().IntVarP(&messagesCount, "count", "n", 10, "Number of messages to show")
+	messagesCmd.Flags().IntVarP(&messagesCount, "count", "n", 10, "Number of messages to show, at most 100 on Twitter (conversations with --by-conversation)")
+	messagesCmd.Flags().BoolVar(&messagesByConversation, "by-conversation", false, "Group messages into conversations")
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hev/socials/internal/linkedin"
+	"github.com/hev/socials/internal/twitter"
+	"github.com/spf13/cobra"
+)
+
+// pageFlags are the pagination flags shared by list commands.
+type pageFlags struct {
+	count  int
+	limit  int
+	all    bool
+	cursor string
+	since  string
+	until  string
+}
+
+func addPageFlags(cmd *cobra.Command, f *pageFlags, noun string) {
+	cmd.Flags().IntVarP(&f.count, "count", "n", 10, fmt.Sprintf("Number of %s per page", noun))
+	cmd.Flags().IntVar(&f.limit, "limit", 0, fmt.Sprintf("Fetch pages until this many %s have been returned", noun))
+	cmd.Flags().BoolVar(&f.all, "all", false, "Fetch every page")
+	cmd.Flags().StringVar(&f.cursor, "cursor", "", "Resume from the next_cursor of a previous page")
+	cmd.Flags().StringVar(&f.since, "since", "", "Only newer items: a time (RFC3339, YYYY-MM-DD, or a duration like 24h) or a tweet ID")
+	cmd.Flags().StringVar(&f.until, "until", "", "Only older items: a time or a tweet ID")
+}
+
+func (f *pageFlags) twitter() (twitter.PageOptions, error) {
+	opts := twitter.PageOptions{
+		Count:  f.count,
+		Limit:  f.limit,
+		All:    f.all,
+		Cursor: f.cursor,
+	}
+
+	var err error
+	if opts.SinceID, opts.StartTime, err = parseBound(f.since); err != nil {
+		return opts, fmt.Errorf("invalid --since: %w", err)
+	}
+	if opts.UntilID, opts.EndTime, err = parseBound(f.until); err != nil {
+		return opts, fmt.Errorf("invalid --until: %w", err)
+	}
+	return opts, nil
+}
+
+func (f *pageFlags) linkedin() (linkedin.PageOptions, error) {
+	opts := linkedin.PageOptions{
+		Count:  f.count,
+		Limit:  f.limit,
+		All:    f.all,
+		Cursor: f.cursor,
+	}
+
+	id, since, err := parseBound(f.since)
+	if err != nil {
+		return opts, fmt.Errorf("invalid --since: %w", err)
+	}
+	if id != "" {
+		return opts, fmt.Errorf("invalid --since: linkedin only supports times")
+	}
+	id, until, err := parseBound(f.until)
+	if err != nil {
+		return opts, fmt.Errorf("invalid --until: %w", err)
+	}
+	if id != "" {
+		return opts, fmt.Errorf("invalid --until: linkedin only supports times")
+	}
+
+	opts.Since = since
+	opts.Until = until
+	return opts, nil
+}
+
+// parseBound parses a --since/--until value, which is either a numeric ID
+// or a time: RFC3339, a date, or a duration counted back from now.
+func parseBound(s string) (string, time.Time, error) {
+	if s == "" {
+		return "", time.Time{}, nil
+	}
+	if strings.Trim(s, "0123456789") == "" {
+		return s, time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return "", t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return "", t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return "", time.Now().Add(-d), nil
+	}
+	return "", time.Time{}, fmt.Errorf("%q is not an ID, RFC3339 time, date or duration", s)
+}
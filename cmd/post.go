@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/hev/socials/internal/config"
+	"github.com/hev/socials/internal/history"
 	"github.com/hev/socials/internal/linkedin"
 	"github.com/hev/socials/internal/markdown"
 	"github.com/hev/socials/internal/output"
@@ -15,6 +19,12 @@ var (
 	postFile    string
 	postNetwork string
 	postDryRun  bool
+	postAs      string
+
+	postVisibility     string
+	postDark           bool
+	postDraft          bool
+	postDisableReshare bool
 )
 
 var postCmd = &cobra.Command{
@@ -22,7 +32,10 @@ var postCmd = &cobra.Command{
 	Short: "Post content from a markdown file",
 	Long: `Post content from a markdown file to Twitter and/or LinkedIn.
 Markdown is converted to platform-appropriate formatting.
-Long posts are automatically split into Twitter threads.`,
+Long posts are automatically split into Twitter threads.
+
+--profile takes a comma-separated list to post the same file from several
+accounts, one profile after another.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if postFile == "" {
 			return fmt.Errorf("--file is required")
@@ -33,35 +46,127 @@ Long posts are automatically split into Twitter threads.`,
 			return fmt.Errorf("failed to read post file: %w", err)
 		}
 
+		post, err := markdown.ParsePost(content)
+		if err != nil {
+			return fmt.Errorf("failed to parse post file: %w", err)
+		}
+
 		networks := strings.Split(postNetwork, ",")
 		for i := range networks {
 			networks[i] = strings.TrimSpace(networks[i])
 		}
 
 		if postDryRun {
-			return doDryRun(content, networks)
+			return doDryRun(post, networks)
 		}
 
-		return doPost(content, networks)
+		profiles := profileNames()
+		if len(profiles) < 2 {
+			results, err := doPost(post, networks)
+			if err != nil {
+				return err
+			}
+			return output.Print(results, jsonOutput)
+		}
+		return doFanOut(post, networks, profiles)
 	},
 }
 
-func doDryRun(content string, networks []string) error {
+// doFanOut posts from each profile in turn. Every profile is checked
+// before anything is posted.
+func doFanOut(post *markdown.Post, networks, profiles []string) error {
+	if cfg == nil {
+		return fmt.Errorf("config not found, run 'socials config init' first")
+	}
+	selected := make([]*config.Config, len(profiles))
+	for i, name := range profiles {
+		p, err := fileCfg.ForProfile(name)
+		if err != nil {
+			return err
+		}
+		for _, network := range networks {
+			switch network {
+			case "twitter":
+				err = p.Twitter.Resolve()
+			case "linkedin":
+				err = p.LinkedIn.Resolve()
+			}
+			if err != nil {
+				return fmt.Errorf("profile %s: %w", fileCfg.ResolveProfile(name), err)
+			}
+		}
+		selected[i] = p
+	}
+
+	var results []output.PostResult
+	for i, name := range profiles {
+		switchProfile(name, selected[i])
+		posted, err := doPost(post, networks)
+		for i := range posted {
+			posted[i].Profile = activeProfile()
+		}
+		results = append(results, posted...)
+		if err != nil {
+			// Show what did get posted, so a retry can skip those accounts.
+			if len(results) > 0 {
+				output.Print(results, jsonOutput)
+			}
+			return fmt.Errorf("profile %s: %w", activeProfile(), err)
+		}
+	}
+	return output.Print(results, jsonOutput)
+}
+
+func doDryRun(post *markdown.Post, networks []string) error {
 	var results []output.DryRunResult
 
 	for _, network := range networks {
 		switch network {
 		case "twitter":
-			chunks := markdown.ToTwitter(content)
-			results = append(results, output.DryRunResult{
+			chunks, poll, err := renderTwitter(post)
+			if err != nil {
+				return err
+			}
+			result := output.DryRunResult{
 				Network: "twitter",
 				Chunks:  chunks,
-			})
+			}
+			if poll != nil {
+				result.Poll = &output.PollPreview{
+					Options:         poll.Options,
+					DurationMinutes: poll.DurationMinutes,
+				}
+			}
+			results = append(results, result)
 		case "linkedin":
-			text := markdown.ToLinkedIn(content)
+			opts, err := linkedinOptions(post, filepath.Dir(postFile))
+			if err != nil {
+				return err
+			}
+			text := markdown.ToLinkedIn(post.Body)
+			author := linkedinAuthor(post)
+			if author == "" {
+				author = "person"
+			}
+			preview := &output.LinkedInPostOptions{
+				Author:           author,
+				Visibility:       opts.Visibility,
+				FeedDistribution: opts.FeedDistribution,
+				LifecycleState:   opts.LifecycleState,
+				ReshareDisabled:  opts.DisableReshare,
+			}
+			if a := opts.Article; a != nil {
+				preview.Article = &output.ArticlePreview{
+					URL:         a.Source,
+					Title:       a.Title,
+					Description: a.Description,
+					Thumbnail:   a.ThumbnailPath,
+				}
+			}
 			results = append(results, output.DryRunResult{
-				Network: "linkedin",
-				Chunks:  []string{text},
+				Network:  "linkedin",
+				Chunks:   []string{text},
+				LinkedIn: preview,
 			})
 		default:
 			return fmt.Errorf("unknown network: %s", network)
@@ -71,9 +176,11 @@ func doDryRun(content string, networks []string) error {
 	return output.Print(results, jsonOutput)
 }
 
-func doPost(content string, networks []string) error {
+// doPost posts to each network with the current profile's accounts. On
+// error it still returns the results of the networks posted so far.
+func doPost(post *markdown.Post, networks []string) ([]output.PostResult, error) {
 	if cfg == nil {
-		return fmt.Errorf("config not found, run 'socials config init' first")
+		return nil, fmt.Errorf("config not found, run 'socials config init' first")
 	}
 
 	var results []output.PostResult
@@ -81,49 +188,167 @@ func doPost(content string, networks []string) error {
 	for _, network := range networks {
 		switch network {
 		case "twitter":
-			if !cfg.HasTwitter() {
-				return fmt.Errorf("twitter not configured, run 'socials config init'")
+			client, err := newTwitterClient()
+			if err != nil {
+				return results, err
+			}
+			chunks, poll, err := renderTwitter(post)
+			if err != nil {
+				return results, err
 			}
-			client := twitter.NewClient(&cfg.Twitter)
-			chunks := markdown.ToTwitter(content)
 
-			if len(chunks) == 1 {
+			if len(chunks) == 1 && poll == nil {
 				result, err := client.PostTweet(chunks[0])
 				if err != nil {
-					return fmt.Errorf("failed to post to twitter: %w", err)
+					return results, fmt.Errorf("failed to post to twitter: %w", err)
 				}
 				results = append(results, *result)
 			} else {
-				threadResults, err := client.PostThread(chunks)
+				opts, err := withPoll(twitter.ThreadOptions{}, chunks, poll)
 				if err != nil {
-					return fmt.Errorf("failed to post twitter thread: %w", err)
+					return results, err
 				}
+				threadResults, err := client.PostThreadWith(chunks, opts)
+				// Record whatever made it out, so a half-posted thread
+				// can still be cleaned up with 'delete --thread'.
+				recordThread("twitter", threadResults)
 				results = append(results, threadResults...)
+				if err != nil {
+					return results, fmt.Errorf("failed to post twitter thread: %w", err)
+				}
 			}
 
 		case "linkedin":
-			if !cfg.HasLinkedIn() {
-				return fmt.Errorf("linkedin not configured, run 'socials config init'")
+			client, err := newLinkedInClient()
+			if err != nil {
+				return results, err
 			}
-			client := linkedin.NewClient(&cfg.LinkedIn)
-			text := markdown.ToLinkedIn(content)
+			if err := client.ActAs(linkedinAuthor(post)); err != nil {
+				return results, err
+			}
+			opts, err := linkedinOptions(post, filepath.Dir(postFile))
+			if err != nil {
+				return results, err
+			}
+			text := markdown.ToLinkedIn(post.Body)
 
-			result, err := client.CreatePost(text)
+			result, err := client.CreatePost(text, opts)
 			if err != nil {
-				return fmt.Errorf("failed to post to linkedin: %w", err)
+				return results, fmt.Errorf("failed to post to linkedin: %w", err)
 			}
 			results = append(results, *result)
 
 		default:
-			return fmt.Errorf("unknown network: %s", network)
+			return results, fmt.Errorf("unknown network: %s", network)
 		}
 	}
 
-	return output.Print(results, jsonOutput)
+	return results, nil
+}
+
+// renderTwitter converts a post into thread chunks plus its poll, if it
+// declares one. The poll is validated here so dry runs catch the same
+// problems the API would reject.
+func renderTwitter(post *markdown.Post) ([]string, *markdown.Poll, error) {
+	body, poll, err := post.TwitterPoll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if poll != nil {
+		if err := poll.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid poll: %w", err)
+		}
+	}
+	chunks := markdown.ToTwitter(body)
+	if len(chunks) == 0 || (len(chunks) == 1 && chunks[0] == "") {
+		if poll != nil {
+			return nil, nil, fmt.Errorf("post has no text: a poll needs its question in the body")
+		}
+		return nil, nil, fmt.Errorf("post has no text")
+	}
+	return chunks, poll, nil
+}
+
+// withPoll attaches poll, if any, to the last tweet of the thread. Twitter
+// rejects tweets that quote and have a poll, which happens when the thread
+// is a single tweet.
+func withPoll(opts twitter.ThreadOptions, chunks []string, poll *markdown.Poll) (twitter.ThreadOptions, error) {
+	if poll == nil {
+		return opts, nil
+	}
+	if opts.Quote != "" && len(chunks) == 1 {
+		return opts, fmt.Errorf("a quote tweet can't have a poll; move the poll to a later tweet of the thread")
+	}
+	opts.PollOptions = poll.Options
+	opts.PollDurationMinutes = poll.DurationMinutes
+	return opts, nil
+}
+
+// linkedinAuthor returns who to post as on LinkedIn: the --as flag wins
+// over the post's front matter.
+func linkedinAuthor(post *markdown.Post) string {
+	if postAs != "" {
+		return postAs
+	}
+	return post.Meta.As
+}
+
+// linkedinOptions merges the distribution flags with the post's front
+// matter, resolves its article card relative to dir and validates the
+// result, so problems surface before any API call.
+func linkedinOptions(post *markdown.Post, dir string) (linkedin.PostOptions, error) {
+	opts := linkedin.PostOptions{
+		Visibility:     post.Meta.Visibility,
+		DisableReshare: post.Meta.DisableReshare || postDisableReshare,
+	}
+	if postVisibility != "" {
+		opts.Visibility = postVisibility
+	}
+	if post.Meta.Dark || postDark {
+		opts.FeedDistribution = "NONE"
+	}
+	if post.Meta.Draft || postDraft {
+		opts.LifecycleState = "DRAFT"
+	}
+
+	if err := opts.Normalize(linkedin.IsOrganizationAuthor(linkedinAuthor(post))); err != nil {
+		return opts, fmt.Errorf("invalid linkedin options: %w", err)
+	}
+
+	article, err := post.Article(dir)
+	if err != nil {
+		return opts, fmt.Errorf("invalid article: %w", err)
+	}
+	if article != nil {
+		opts.Article = &linkedin.Article{
+			Source:        article.URL,
+			Title:         article.Title,
+			Description:   article.Description,
+			ThumbnailPath: article.Thumbnail,
+		}
+	}
+	return opts, nil
+}
+
+// recordThread remembers a posted thread so 'socials delete --thread' can
+// find all of its parts later. Failing to record is not fatal.
+func recordThread(network string, results []output.PostResult) {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	if err := history.Record(network, ids); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+	}
 }
 
 func init() {
 	postCmd.Flags().StringVarP(&postFile, "file", "f", "", "Path to markdown file to post")
 	postCmd.Flags().StringVarP(&postNetwork, "network", "n", "twitter", "Networks to post to (comma-separated: twitter,linkedin)")
 	postCmd.Flags().BoolVar(&postDryRun, "dry-run", false, "Preview the post without publishing")
+	postCmd.Flags().StringVar(&postAs, "as", "", "LinkedIn author: person (default) or org")
+	postCmd.Flags().StringVar(&postVisibility, "visibility", "", "LinkedIn visibility: public (default), connections or logged_in")
+	postCmd.Flags().BoolVar(&postDark, "dark", false, "Publish to LinkedIn without distributing to the main feed")
+	postCmd.Flags().BoolVar(&postDraft, "draft", false, "Save the LinkedIn post as a draft instead of publishing")
+	postCmd.Flags().BoolVar(&postDisableReshare, "disable-reshare", false, "Prevent others from resharing the LinkedIn post")
 }
@@ -3,16 +3,25 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/hev/socials/internal/config"
+	"github.com/hev/socials/internal/linkedin"
+	"github.com/hev/socials/internal/twitter"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfg        *config.Config
-	verbose    bool
-	jsonOutput bool
-	configPath string
+	cfg         *config.Config
+	verbose     bool
+	jsonOutput  bool
+	configPath  string
+	profileFlag string
+
+	// fileCfg is the config as loaded, with every profile; cfg holds the
+	// accounts of the selected one, named by profile.
+	fileCfg *config.Config
+	profile string
 )
 
 var rootCmd = &cobra.Command{
@@ -22,11 +31,20 @@ var rootCmd = &cobra.Command{
 Built for AI agents and humans alike, with structured JSON output
 for programmatic consumption.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Commands that write the config, including refreshed tokens,
+		// write back to the file given with --config.
+		config.UsePath(configPath)
+
 		switch cmd.Name() {
-		case "init", "set", "help", "completion":
+		case "init", "set", "use", "migrate-secrets", "login", "lint", "help", "completion":
 			return nil
 		}
 
+		names := profileNames()
+		if len(names) > 1 && cmd != postCmd {
+			return fmt.Errorf("only 'post' accepts several profiles")
+		}
+
 		var err error
 		if configPath != "" {
 			cfg, err = config.LoadFrom(configPath)
@@ -38,11 +56,100 @@ for programmatic consumption.`,
 			if verbose {
 				fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
 			}
+			profile = config.DefaultProfile
+			return nil
 		}
-		return nil
+		fileCfg = cfg
+
+		var name string
+		if len(names) > 0 {
+			name = names[0]
+		}
+		return useProfile(name)
 	},
 }
 
+// profileNames returns the profiles selected with --profile or
+// SOCIALS_PROFILE, in order. None means the configured default.
+func profileNames() []string {
+	value := profileFlag
+	if value == "" {
+		value = os.Getenv("SOCIALS_PROFILE")
+	}
+
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// useProfile switches cfg to the accounts of the named profile.
+func useProfile(name string) error {
+	selected, err := fileCfg.ForProfile(name)
+	if err != nil {
+		return err
+	}
+	switchProfile(name, selected)
+	return nil
+}
+
+// switchProfile makes selected, from ForProfile, the accounts in use.
+func switchProfile(name string, selected *config.Config) {
+	profile = fileCfg.ResolveProfile(name)
+	cfg = selected
+	cfg.Twitter.SaveToken = tokenSaver(profile, "twitter")
+	cfg.LinkedIn.SaveToken = tokenSaver(profile, "linkedin")
+	if verbose {
+		cfg.LinkedIn.Logf = func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}
+	}
+
+	if verbose {
+		for _, w := range expiryWarnings(cfg) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+	}
+}
+
+// activeProfile names the account set in use, for keying local state such
+// as feed checkpoints.
+func activeProfile() string {
+	if profile == "" {
+		return config.DefaultProfile
+	}
+	return profile
+}
+
+func newTwitterClient() (*twitter.Client, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config not found, run 'socials config init' first")
+	}
+	if !cfg.HasTwitter() {
+		return nil, fmt.Errorf("twitter not configured, run 'socials config init'")
+	}
+	if err := cfg.Twitter.Resolve(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", activeProfile(), err)
+	}
+	return twitter.NewClient(&cfg.Twitter), nil
+}
+
+func newLinkedInClient() (*linkedin.Client, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config not found, run 'socials config init' first")
+	}
+	if !cfg.HasLinkedIn() {
+		return nil, fmt.Errorf("linkedin not configured, run 'socials config init'")
+	}
+	if err := cfg.LinkedIn.Resolve(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", activeProfile(), err)
+	}
+	return linkedin.NewClient(&cfg.LinkedIn), nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -54,9 +161,29 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show verbose output")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to config file")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Profile to use (default from config or $SOCIALS_PROFILE)")
 
 	rootCmd.AddCommand(feedCmd)
 	rootCmd.AddCommand(postCmd)
+	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(messagesCmd)
+	rootCmd.AddCommand(dmCmd)
+	rootCmd.AddCommand(mentionsCmd)
+	rootCmd.AddCommand(userCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(threadCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(editCmd)
+	rootCmd.AddCommand(replyCmd)
+	rootCmd.AddCommand(quoteCmd)
+	rootCmd.AddCommand(retweetCmd)
+	rootCmd.AddCommand(unretweetCmd)
+	rootCmd.AddCommand(likeCmd)
+	rootCmd.AddCommand(unlikeCmd)
+	rootCmd.AddCommand(commentsCmd)
+	rootCmd.AddCommand(commentCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(doctorCmd)
 }
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchPage pageFlags
+	searchSort string
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search twitter <query>",
+	Short: "Search recent tweets",
+	Long: `Search tweets from the last seven days. The query supports Twitter's
+full operator syntax, for example:
+
+  socials search twitter "from:golang -is:retweet"
+  socials search twitter "#golang has:links lang:en" --sort relevancy`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "twitter" {
+			return fmt.Errorf("search is only supported for twitter")
+		}
+
+		opts, err := searchPage.twitter()
+		if err != nil {
+			return err
+		}
+		client, err := newTwitterClient()
+		if err != nil {
+			return err
+		}
+
+		tweets, err := client.SearchRecent(args[1], searchSort, opts)
+		if err != nil {
+			return err
+		}
+		return output.Print(*tweets, jsonOutput)
+	},
+}
+
+func init() {
+	addPageFlags(searchCmd, &searchPage, "tweets")
+	searchCmd.Flags().StringVar(&searchSort, "sort", "", "Sort order: recency or relevancy")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var threadCmd = &cobra.Command{
+	Use:   "thread twitter <tweet-id>",
+	Short: "View the conversation around a tweet",
+	Long: `Fetch every tweet in the conversation containing a tweet, as a reply
+tree. JSON output lists tweets depth first with parent_id and depth; human
+output indents replies under their parent.
+
+Replies are found with recent search, so only the last seven days of a
+conversation are included.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "twitter" {
+			return fmt.Errorf("threads are only supported for twitter")
+		}
+
+		client, err := newTwitterClient()
+		if err != nil {
+			return err
+		}
+
+		thread, err := client.GetConversation(args[1])
+		if err != nil {
+			return err
+		}
+		return output.Print(thread, jsonOutput)
+	},
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var userCmd = &cobra.Command{
+	Use:   "user twitter <@handle|id>",
+	Short: "Look up a profile",
+	Long: `Show a Twitter profile: bio, follower counts, verification and join date.
+Numeric arguments are treated as user IDs; prefix a handle with @ if it is
+all digits.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "twitter" {
+			return fmt.Errorf("user lookup is only supported for twitter")
+		}
+
+		client, err := newTwitterClient()
+		if err != nil {
+			return err
+		}
+
+		user, err := client.GetUser(args[1])
+		if err != nil {
+			return err
+		}
+		return output.Print(*user, jsonOutput)
+	},
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hev/socials/internal/linkedin"
+	"github.com/hev/socials/internal/output"
+	"github.com/hev/socials/internal/state"
+	"github.com/hev/socials/internal/twitter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// maxSeen bounds how many item keys each source remembers for
+	// de-duplication.
+	maxSeen = 5000
+
+	maxBackoffDoublings = 3
+)
+
+var (
+	watchInterval     time.Duration
+	watchCount        int
+	watchSkipExisting bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch feed|messages|mentions [twitter|linkedin]",
+	Short: "Stream new items as NDJSON",
+	Long: `Poll a feed, your messages or your mentions and write each new item as
+one JSON object per line, until interrupted. Without a network every
+configured network that supports the kind is watched.
+
+Polling backs off to stay within the API's rate limits, so the effective
+interval can be longer than --interval.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind := args[0]
+		if kind != "feed" && kind != "messages" && kind != "mentions" {
+			return fmt.Errorf("unknown kind: %s (use 'feed', 'messages' or 'mentions')", kind)
+		}
+
+		if cfg == nil {
+			return fmt.Errorf("config not found, run 'socials config init' first")
+		}
+
+		var networks []string
+		if len(args) == 2 {
+			networks = []string{args[1]}
+		} else {
+			if cfg.HasTwitter() {
+				networks = append(networks, "twitter")
+			}
+			if cfg.HasLinkedIn() && kind != "mentions" {
+				networks = append(networks, "linkedin")
+			}
+			if len(networks) == 0 {
+				return fmt.Errorf("no network configured for %s, run 'socials config init'", kind)
+			}
+		}
+
+		var sources []*watchSource
+		for _, network := range networks {
+			src, err := newWatchSource(kind, network)
+			if err != nil {
+				return err
+			}
+			sources = append(sources, src)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		var mu sync.Mutex
+		emit := func(ev output.WatchEvent) error {
+			mu.Lock()
+			defer mu.Unlock()
+			return output.PrintNDJSON(ev)
+		}
+
+		var wg sync.WaitGroup
+		for _, src := range sources {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				src.run(ctx, emit)
+			}()
+		}
+		wg.Wait()
+		return nil
+	},
+}
+
+type watchItem struct {
+	key  string
+	item any
+}
+
+// watchSource polls one kind of item on one network. poll returns items
+// oldest first; delay says how long rate limits want us to wait before
+// polling again.
+type watchSource struct {
+	network string
+	kind    string
+	poll    func() ([]watchItem, error)
+	delay   func() time.Duration
+}
+
+func newWatchSource(kind, network string) (*watchSource, error) {
+	src := &watchSource{network: network, kind: kind}
+
+	switch network {
+	case "twitter":
+		client, err := newTwitterClient()
+		if err != nil {
+			return nil, err
+		}
+		src.delay = func() time.Duration { return twitterDelay(client.RateLimit()) }
+
+		// Feed and mentions only ask for tweets newer than the newest one
+		// seen so far, all of them once there is one, so bursts larger than
+		// --count aren't skipped.
+		var newest state.Checkpoint
+		pollTweets := func(list func(twitter.PageOptions) (*output.TweetPage, error)) ([]watchItem, error) {
+			page, err := list(twitter.PageOptions{Count: watchCount, SinceID: newest.SinceID, All: newest.SinceID != ""})
+			if err != nil {
+				return nil, err
+			}
+			var items []watchItem
+			for _, t := range slices.Backward(page.Tweets) {
+				newest.SeeID(t.ID)
+				items = append(items, watchItem{key: t.ID, item: t})
+			}
+			return items, nil
+		}
+
+		switch kind {
+		case "feed":
+			src.poll = func() ([]watchItem, error) { return pollTweets(client.GetTimeline) }
+		case "mentions":
+			src.poll = func() ([]watchItem, error) { return pollTweets(client.GetMentions) }
+		case "messages":
+			src.poll = func() ([]watchItem, error) {
+				messages, err := client.GetDirectMessages(watchCount)
+				if err != nil {
+					return nil, err
+				}
+				var items []watchItem
+				for _, m := range slices.Backward(messages) {
+					items = append(items, watchItem{key: m.ID, item: m})
+				}
+				return items, nil
+			}
+		}
+
+	case "linkedin":
+		if kind == "mentions" {
+			return nil, fmt.Errorf("mentions are only supported for twitter")
+		}
+		client, err := newLinkedInClient()
+		if err != nil {
+			return nil, err
+		}
+		// LinkedIn sends no rate limit headers, so it is only paced by
+		// backing off on 429s.
+		src.delay = func() time.Duration { return 0 }
+
+		switch kind {
+		case "feed":
+			src.poll = func() ([]watchItem, error) {
+				page, err := client.GetPosts(linkedin.PageOptions{Count: watchCount})
+				if err != nil {
+					return nil, err
+				}
+				var items []watchItem
+				for _, p := range slices.Backward(page.Posts) {
+					items = append(items, watchItem{key: p.ID, item: p})
+				}
+				return items, nil
+			}
+		case "messages":
+			src.poll = func() ([]watchItem, error) {
+				messages, err := client.GetMessages(watchCount)
+				if err != nil {
+					return nil, err
+				}
+				var items []watchItem
+				for _, m := range slices.Backward(messages) {
+					items = append(items, watchItem{key: m.ID, item: m})
+				}
+				return items, nil
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown network: %s (use 'twitter' or 'linkedin')", network)
+	}
+
+	return src, nil
+}
+
+func (s *watchSource) run(ctx context.Context, emit func(output.WatchEvent) error) {
+	seen := map[string]bool{}
+	var order []string
+	first := true
+	// limited counts consecutive rate limit errors; each one doubles the
+	// back-off, up to maxBackoffDoublings times.
+	limited := 0
+
+	for {
+		wait := watchInterval
+
+		items, err := s.poll()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "watch %s %s: %s\n", s.kind, s.network, err)
+			if backoff := rateLimitWait(err); backoff > 0 {
+				wait = max(wait, backoff<<min(limited, maxBackoffDoublings))
+				limited++
+			}
+		} else {
+			limited = 0
+			for _, it := range items {
+				if seen[it.key] {
+					continue
+				}
+				seen[it.key] = true
+				order = append(order, it.key)
+
+				if first && watchSkipExisting {
+					continue
+				}
+				ev := output.WatchEvent{
+					Network: s.network,
+					Kind:    s.kind,
+					Item:    it.item,
+					SeenAt:  time.Now().Format(time.RFC3339),
+				}
+				if err := emit(ev); err != nil {
+					fmt.Fprintf(os.Stderr, "watch %s %s: %s\n", s.kind, s.network, err)
+					return
+				}
+			}
+			first = false
+
+			if len(order) > maxSeen {
+				for _, key := range order[:len(order)-maxSeen] {
+					delete(seen, key)
+				}
+				order = slices.Clone(order[len(order)-maxSeen:])
+			}
+			wait = max(wait, s.delay())
+		}
+
+		if verbose {
+			fmt.Fprintf(os.Stderr, "watch %s %s: next poll in %s\n", s.kind, s.network, wait.Round(time.Second))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// twitterDelay spreads the requests left in the current rate limit window
+// over the time until it resets.
+func twitterDelay(rl twitter.RateLimit) time.Duration {
+	untilReset := time.Until(rl.Reset)
+	if rl.Reset.IsZero() || untilReset <= 0 {
+		return 0
+	}
+	if rl.Remaining <= 0 {
+		return untilReset + time.Second
+	}
+	return untilReset / time.Duration(rl.Remaining)
+}
+
+// rateLimitWait returns how long to back off after err, which is only
+// non-zero for rate limit errors.
+func rateLimitWait(err error) time.Duration {
+	var reset time.Time
+	var twErr *twitter.RateLimitError
+	var liErr *linkedin.RateLimitError
+	switch {
+	case errors.As(err, &twErr):
+		reset = twErr.Reset
+	case errors.As(err, &liErr):
+		reset = liErr.Reset
+	default:
+		return 0
+	}
+
+	if reset.IsZero() {
+		return 15 * time.Minute
+	}
+	return time.Until(reset) + time.Second
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Minute, "Minimum time between polls")
+	watchCmd.Flags().IntVarP(&watchCount, "count", "n", 20, "Number of items to fetch per poll")
+	watchCmd.Flags().BoolVar(&watchSkipExisting, "skip-existing", false, "Don't emit the items already there when watching starts")
+}
@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClockSkew is how far the server's clock, going by the Date header of a
+// response that just arrived, is ahead of the local one. The header only
+// has second precision. ok is false without a usable Date header.
+func ClockSkew(h http.Header) (skew time.Duration, ok bool) {
+	date, err := http.ParseTime(h.Get("Date"))
+	if err != nil {
+		return 0, false
+	}
+	return date.Sub(time.Now().Truncate(time.Second)), true
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+)
+
+const callbackPath = "/callback"
+
+// Loopback receives the authorization redirect on a local HTTP server, so
+// the user never has to copy codes around.
+type Loopback struct {
+	listener net.Listener
+	server   *http.Server
+	state    string
+	results  chan callbackResult
+}
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// Listen starts the callback server on 127.0.0.1:port. Providers only
+// redirect to registered URIs, so the port has to match the app's
+// settings. The redirect must carry state, which guards against codes
+// from requests we didn't start.
+func Listen(port int, state string) (*Loopback, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+
+	l := &Loopback{
+		listener: listener,
+		state:    state,
+		results:  make(chan callbackResult, 1),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, l.handleCallback)
+	l.server = &http.Server{Handler: mux}
+	go l.server.Serve(listener)
+
+	return l, nil
+}
+
+// RedirectURI is the URI to register with the provider and pass in the
+// authorization request.
+func (l *Loopback) RedirectURI() string {
+	return fmt.Sprintf("http://localhost:%d%s", l.listener.Addr().(*net.TCPAddr).Port, callbackPath)
+}
+
+// Wait blocks until the provider redirects back and returns the
+// authorization code.
+func (l *Loopback) Wait(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("timed out waiting for authorization")
+	case r := <-l.results:
+		return r.code, r.err
+	}
+}
+
+func (l *Loopback) Close() error {
+	return l.server.Close()
+}
+
+func (l *Loopback) handleCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var result callbackResult
+	switch {
+	case q.Get("error") != "":
+		msg := q.Get("error")
+		if desc := q.Get("error_description"); desc != "" {
+			msg += ": " + desc
+		}
+		result.err = fmt.Errorf("authorization denied (%s)", msg)
+	case q.Get("state") != l.state:
+		result.err = fmt.Errorf("authorization state mismatch, try logging in again")
+	case q.Get("code") == "":
+		result.err = fmt.Errorf("authorization callback had no code")
+	default:
+		result.code = q.Get("code")
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if result.err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "<p>Login failed: %s</p>", html.EscapeString(result.err.Error()))
+	} else {
+		fmt.Fprint(w, "<p>Authorization received. You can close this window and return to the terminal.</p>")
+	}
+
+	// Only the first callback counts.
+	select {
+	case l.results <- result:
+	default:
+	}
+}
+
+// RandomString returns n random bytes, base64url encoded without padding,
+// for state parameters and PKCE verifiers.
+func RandomString(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// OpenBrowser opens url in the user's browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
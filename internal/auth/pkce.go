@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// PKCE is a proof key for a public client's code exchange (RFC 7636): the
+// challenge goes with the authorization request, the verifier with the
+// token request.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+func NewPKCE() PKCE {
+	verifier := RandomString(32)
+	sum := sha256.Sum256([]byte(verifier))
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}
+}
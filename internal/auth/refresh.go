@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// expiryMargin refreshes tokens slightly early, so they don't expire
+// between the check and the request.
+const expiryMargin = time.Minute
+
+// Refresher renews an access token with a refresh token. Save is called
+// with every new token so it can be persisted; it may be nil. With
+// Disabled set, Refresh fails with that reason instead of calling the
+// token endpoint.
+type Refresher struct {
+	Endpoint     Endpoint
+	RefreshToken string
+	ExpiresAt    time.Time
+	Save         func(*Token)
+	Disabled     string
+
+	mu sync.Mutex
+}
+
+// Expired reports whether the access token has expired, or is about to.
+// Unknown expiry times count as valid.
+func (r *Refresher) Expired() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.ExpiresAt.IsZero() && time.Until(r.ExpiresAt) < expiryMargin
+}
+
+// Expiry returns when the current access token expires, which changes
+// with every refresh.
+func (r *Refresher) Expiry() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ExpiresAt
+}
+
+// Refresh obtains a new access token and returns it.
+func (r *Refresher) Refresh() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Disabled != "" {
+		return "", errors.New(r.Disabled)
+	}
+	token, err := r.Endpoint.Refresh(r.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	r.RefreshToken = token.RefreshToken
+	r.ExpiresAt = token.ExpiresAt
+
+	if r.Save != nil {
+		r.Save(token)
+	}
+	return token.AccessToken, nil
+}
+
+// ParseTime reads an expiry written by FormatTime; empty or malformed
+// values give the zero time.
+func ParseTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Endpoint is a provider's token endpoint and the app's credentials.
+// Providers differ in how the client authenticates: LinkedIn takes the
+// credentials in the form, Twitter as HTTP basic auth.
+type Endpoint struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	BasicAuth    bool
+}
+
+// Token is an OAuth 2.0 token response. Expiry times are computed when
+// the response arrives.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+
+	ExpiresIn             int64 `json:"expires_in"`
+	RefreshTokenExpiresIn int64 `json:"refresh_token_expires_in"`
+
+	ExpiresAt             time.Time `json:"-"`
+	RefreshTokenExpiresAt time.Time `json:"-"`
+}
+
+// Exchange trades an authorization code for a token.
+func (e Endpoint) Exchange(code, redirectURI string, extra url.Values) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	for k, v := range extra {
+		form[k] = v
+	}
+
+	token, err := e.requestToken(form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	return token, nil
+}
+
+// Refresh trades a refresh token for a new access token. Providers may
+// rotate the refresh token too; if they don't, the old one is kept.
+func (e Endpoint) Refresh(refreshToken string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	token, err := e.requestToken(form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func (e Endpoint) requestToken(form url.Values) (*Token, error) {
+	if !e.BasicAuth || e.ClientSecret == "" {
+		form.Set("client_id", e.ClientID)
+	}
+	if !e.BasicAuth && e.ClientSecret != "" {
+		form.Set("client_secret", e.ClientSecret)
+	}
+
+	req, err := http.NewRequest("POST", e.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if e.BasicAuth && e.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(e.ClientID), url.QueryEscape(e.ClientSecret))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			if apiErr.Description != "" {
+				return nil, fmt.Errorf("%s (%d): %s", apiErr.Error, resp.StatusCode, apiErr.Description)
+			}
+			return nil, fmt.Errorf("%s (%d)", apiErr.Error, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("token endpoint error (%d): %s", resp.StatusCode, string(data))
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response had no access token")
+	}
+
+	now := time.Now()
+	if token.ExpiresIn > 0 {
+		token.ExpiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.RefreshTokenExpiresIn > 0 {
+		token.RefreshTokenExpiresAt = now.Add(time.Duration(token.RefreshTokenExpiresIn) * time.Second)
+	}
+	return &token, nil
+}
+
+// FormatTime formats an expiry for the config file, leaving unknown
+// expiries empty.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
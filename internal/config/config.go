@@ -1,16 +1,41 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hev/socials/internal/auth"
 	"github.com/spf13/viper"
 )
 
+// DefaultProfile names the accounts configured at the top level of the
+// config file.
+const DefaultProfile = "default"
+
 type Config struct {
 	Twitter  TwitterConfig  `mapstructure:"twitter"`
 	LinkedIn LinkedInConfig `mapstructure:"linkedin"`
+
+	// DefaultProfile is the profile used when none is selected. Empty
+	// means the top-level accounts.
+	DefaultProfile string             `mapstructure:"default_profile"`
+	Profiles       map[string]Profile `mapstructure:"profiles"`
+
+	// ExpiryWarningDays is how far ahead to warn about tokens that will
+	// expire and can't be refreshed. Zero means a week.
+	ExpiryWarningDays int `mapstructure:"expiry_warning_days"`
+}
+
+// Profile is a named set of accounts, e.g. a brand next to a personal
+// account.
+type Profile struct {
+	Twitter  TwitterConfig  `mapstructure:"twitter"`
+	LinkedIn LinkedInConfig `mapstructure:"linkedin"`
 }
 
 type TwitterConfig struct {
@@ -19,13 +44,60 @@ type TwitterConfig struct {
 	AccessToken       string `mapstructure:"access_token"`
 	AccessTokenSecret string `mapstructure:"access_token_secret"`
 	UserID            string `mapstructure:"user_id"`
+
+	// AuthMode is "oauth1" (the default, using the keys above) or
+	// "oauth2" for user tokens from 'socials auth login twitter --oauth2'.
+	AuthMode          string `mapstructure:"auth_mode"`
+	ClientID          string `mapstructure:"client_id"`
+	ClientSecret      string `mapstructure:"client_secret"`
+	OAuth2AccessToken string `mapstructure:"oauth2_access_token"`
+	RefreshToken      string `mapstructure:"refresh_token"`
+	ExpiresAt         string `mapstructure:"expires_at"`
+	Scopes            string `mapstructure:"scopes"`
+
+	// SaveToken persists refreshed OAuth 2.0 tokens. It isn't part of the
+	// file; commands set it for the profile in use.
+	SaveToken func(*auth.Token) `mapstructure:"-"`
+	// FixedRefreshToken is set by ForProfile when refresh_token is an
+	// env:, file: or cmd: reference. Twitter replaces the refresh token on
+	// every refresh, and the new one couldn't be written back, so such
+	// tokens are never used to refresh.
+	FixedRefreshToken bool `mapstructure:"-"`
+
+	resolved bool
+}
+
+// UsesOAuth2 reports whether requests are signed with an OAuth 2.0 user
+// token rather than OAuth 1.0a.
+func (t *TwitterConfig) UsesOAuth2() bool {
+	return t.AuthMode == "oauth2"
 }
 
 type LinkedInConfig struct {
-	AccessToken string `mapstructure:"access_token"`
-	PersonURN   string `mapstructure:"person_urn"`
+	AccessToken     string `mapstructure:"access_token"`
+	PersonURN       string `mapstructure:"person_urn"`
+	OrganizationURN string `mapstructure:"organization_urn"`
+
+	// Set by 'socials auth login linkedin'. Expiry times are RFC3339.
+	ClientID              string `mapstructure:"client_id"`
+	ClientSecret          string `mapstructure:"client_secret"`
+	RefreshToken          string `mapstructure:"refresh_token"`
+	ExpiresAt             string `mapstructure:"expires_at"`
+	RefreshTokenExpiresAt string `mapstructure:"refresh_token_expires_at"`
+	Scopes                string `mapstructure:"scopes"`
+
+	// SaveToken persists refreshed tokens, like TwitterConfig.SaveToken.
+	SaveToken func(*auth.Token) `mapstructure:"-"`
+	// Logf reports problems that don't fail a command, such as names that
+	// couldn't be looked up. Like SaveToken it is set at runtime.
+	Logf func(format string, args ...any) `mapstructure:"-"`
+
+	resolved bool
 }
 
+// ErrNotFound is returned when there is no config file to load.
+var ErrNotFound = errors.New("config not found, run 'socials config init' first")
+
 func ConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -34,7 +106,19 @@ func ConfigDir() (string, error) {
 	return filepath.Join(home, ".config", "socials"), nil
 }
 
+// pathOverride replaces the default config file, see UsePath.
+var pathOverride string
+
+// UsePath makes every load and save use the config file at path, as given
+// with --config. State and the fallback keyring stay in ConfigDir.
+func UsePath(path string) {
+	pathOverride = path
+}
+
 func ConfigPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
 	dir, err := ConfigDir()
 	if err != nil {
 		return "", err
@@ -42,47 +126,272 @@ func ConfigPath() (string, error) {
 	return filepath.Join(dir, "config.yaml"), nil
 }
 
+// envKeys are the settings that can come from SOCIALS_* environment
+// variables even when the config file doesn't mention them. Keys under
+// profiles: can be overridden the same way once they exist in the file.
+// Keep it in step with the mapstructure tags of Config, TwitterConfig and
+// LinkedInConfig; a key missing here is silently ignored in the environment.
+var envKeys = []string{
+	"default_profile",
+	"expiry_warning_days",
+	"twitter.api_key",
+	"twitter.api_key_secret",
+	"twitter.access_token",
+	"twitter.access_token_secret",
+	"twitter.user_id",
+	"twitter.auth_mode",
+	"twitter.client_id",
+	"twitter.client_secret",
+	"twitter.oauth2_access_token",
+	"twitter.refresh_token",
+	"twitter.expires_at",
+	"twitter.scopes",
+	"linkedin.access_token",
+	"linkedin.person_urn",
+	"linkedin.organization_urn",
+	"linkedin.client_id",
+	"linkedin.client_secret",
+	"linkedin.refresh_token",
+	"linkedin.expires_at",
+	"linkedin.refresh_token_expires_at",
+	"linkedin.scopes",
+}
+
+// Load reads the config file and applies SOCIALS_* environment overrides,
+// e.g. SOCIALS_TWITTER_API_KEY for twitter.api_key. Without a config file,
+// the environment alone is enough. Secret references are left as they are
+// until the account that holds them is resolved.
 func Load() (*Config, error) {
-	dir, err := ConfigDir()
+	path, err := ConfigPath()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config dir: %w", err)
 	}
 
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(dir)
-
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			return nil, fmt.Errorf("config not found, run 'socials config init' first")
+	v, err := readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if !envConfigured() {
+			return nil, ErrNotFound
 		}
+		v, err = viper.New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	return unmarshalWithEnv(v)
+}
+
+func LoadFrom(path string) (*Config, error) {
+	v, err := readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config from %s: %w", path, err)
+	}
+
+	return unmarshalWithEnv(v)
+}
+
+// LoadRaw returns the config file exactly as written, without environment
+// overrides or resolved secrets, for commands that save it back.
+func LoadRaw() (*Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config dir: %w", err)
+	}
+
+	v, err := readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
 	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
-
 	return &cfg, nil
 }
 
-func LoadFrom(path string) (*Config, error) {
-	viper.SetConfigFile(path)
+// Set changes a single key of the config file, e.g. "twitter.api_key".
+func Set(key, value string) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config dir: %w", err)
+	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to read config from %s: %w", path, err)
+	v, err := readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		v, err = viper.New(), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
 	}
 
+	v.Set(key, value)
+
 	var cfg Config
-	if err := viper.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+	if err := v.Unmarshal(&cfg); err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	return Save(&cfg)
+}
+
+func readFile(path string) (*viper.Viper, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
 	}
 
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func unmarshalWithEnv(v *viper.Viper) (*Config, error) {
+	v.SetEnvPrefix("SOCIALS")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	for _, key := range envKeys {
+		v.BindEnv(key)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
 	return &cfg, nil
 }
 
+func envConfigured() bool {
+	for _, key := range envKeys {
+		name := "SOCIALS_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		if _, ok := os.LookupEnv(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveProfile returns the profile name to use for name, falling back to
+// the configured default. Names are case-insensitive, like all config keys.
+func (c *Config) ResolveProfile(name string) string {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		return DefaultProfile
+	}
+	return strings.ToLower(name)
+}
+
+// HasProfile reports whether name is the top-level profile or one of the
+// named profiles.
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.Profiles[strings.ToLower(name)]
+	return ok || strings.EqualFold(name, DefaultProfile)
+}
+
+// ProfileNames lists the available profiles, the top-level one first.
+func (c *Config) ProfileNames() []string {
+	names := []string{DefaultProfile}
+	for name := range c.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// ForProfile returns a copy of the config whose Twitter and LinkedIn
+// accounts are those of the named profile. Secret references are left for
+// each account's Resolve.
+func (c *Config) ForProfile(name string) (*Config, error) {
+	name = c.ResolveProfile(name)
+
+	selected := *c
+	if p, ok := c.Profiles[name]; ok {
+		selected.Twitter = p.Twitter
+		selected.LinkedIn = p.LinkedIn
+	} else if name != DefaultProfile {
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	selected.Twitter.FixedRefreshToken = IsFixedReference(selected.Twitter.RefreshToken)
+	return &selected, nil
+}
+
+// ProfileKey returns the config key of a profile's setting, as taken by
+// Set: key itself for the default profile, under profiles: for others.
+func ProfileKey(profile, key string) string {
+	if profile == DefaultProfile || profile == "" {
+		return key
+	}
+	return "profiles." + profile + "." + key
+}
+
+// GetProfile returns the accounts of the named profile as stored, empty
+// for a profile that doesn't exist yet.
+func (c *Config) GetProfile(name string) Profile {
+	name = c.ResolveProfile(name)
+	if p, ok := c.Profiles[name]; ok {
+		return p
+	}
+	if name == DefaultProfile {
+		return Profile{Twitter: c.Twitter, LinkedIn: c.LinkedIn}
+	}
+	return Profile{}
+}
+
+// SetProfile replaces the accounts of the named profile.
+func (c *Config) SetProfile(name string, p Profile) {
+	name = strings.ToLower(name)
+	if name == DefaultProfile {
+		if _, ok := c.Profiles[name]; !ok {
+			c.Twitter = p.Twitter
+			c.LinkedIn = p.LinkedIn
+			return
+		}
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	c.Profiles[name] = p
+}
+
+// TokenExpiry is when one of the accounts' tokens expires. Renewable
+// tokens are refreshed automatically.
+type TokenExpiry struct {
+	Network   string
+	Token     string
+	ExpiresAt time.Time
+	Renewable bool
+}
+
+// TokenExpiries lists the tokens with a known expiry time.
+func (c *Config) TokenExpiries() []TokenExpiry {
+	var expiries []TokenExpiry
+	add := func(network, token, expiresAt string, renewable bool) {
+		if t := auth.ParseTime(expiresAt); !t.IsZero() {
+			expiries = append(expiries, TokenExpiry{network, token, t, renewable})
+		}
+	}
+
+	if c.Twitter.UsesOAuth2() {
+		add("twitter", "access token", c.Twitter.ExpiresAt, c.Twitter.RefreshToken != "" && c.Twitter.ClientID != "" && !c.Twitter.FixedRefreshToken)
+	}
+	add("linkedin", "access token", c.LinkedIn.ExpiresAt, c.LinkedIn.RefreshToken != "" && c.LinkedIn.ClientID != "")
+	add("linkedin", "refresh token", c.LinkedIn.RefreshTokenExpiresAt, false)
+	return expiries
+}
+
 func (c *Config) HasTwitter() bool {
+	if c.Twitter.UsesOAuth2() {
+		return c.Twitter.OAuth2AccessToken != ""
+	}
 	return c.Twitter.APIKey != "" &&
 		c.Twitter.APIKeySecret != "" &&
 		c.Twitter.AccessToken != "" &&
@@ -93,26 +402,40 @@ func (c *Config) HasLinkedIn() bool {
 	return c.LinkedIn.AccessToken != "" && c.LinkedIn.PersonURN != ""
 }
 
+// Save writes cfg to the config file. Pass it a config from LoadRaw, not
+// Load, so environment overrides and resolved secrets stay out of the file.
 func Save(cfg *Config) error {
-	dir, err := ConfigDir()
+	configPath, err := ConfigPath()
 	if err != nil {
 		return fmt.Errorf("failed to get config dir: %w", err)
 	}
 
-	if err := os.MkdirAll(dir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
 		return fmt.Errorf("failed to create config dir: %w", err)
 	}
 
-	viper.Set("twitter.api_key", cfg.Twitter.APIKey)
-	viper.Set("twitter.api_key_secret", cfg.Twitter.APIKeySecret)
-	viper.Set("twitter.access_token", cfg.Twitter.AccessToken)
-	viper.Set("twitter.access_token_secret", cfg.Twitter.AccessTokenSecret)
-	viper.Set("twitter.user_id", cfg.Twitter.UserID)
-	viper.Set("linkedin.access_token", cfg.LinkedIn.AccessToken)
-	viper.Set("linkedin.person_urn", cfg.LinkedIn.PersonURN)
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.Set("twitter", cfg.Twitter.settings())
+	v.Set("linkedin", cfg.LinkedIn.settings())
+	if cfg.DefaultProfile != "" {
+		v.Set("default_profile", cfg.DefaultProfile)
+	}
+	if cfg.ExpiryWarningDays != 0 {
+		v.Set("expiry_warning_days", cfg.ExpiryWarningDays)
+	}
+	if len(cfg.Profiles) > 0 {
+		profiles := map[string]any{}
+		for name, p := range cfg.Profiles {
+			profiles[name] = map[string]any{
+				"twitter":  p.Twitter.settings(),
+				"linkedin": p.LinkedIn.settings(),
+			}
+		}
+		v.Set("profiles", profiles)
+	}
 
-	configPath := filepath.Join(dir, "config.yaml")
-	if err := viper.WriteConfigAs(configPath); err != nil {
+	if err := v.WriteConfigAs(configPath); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -122,3 +445,50 @@ func Save(cfg *Config) error {
 
 	return nil
 }
+
+func (t TwitterConfig) settings() map[string]any {
+	settings := map[string]any{
+		"api_key":             t.APIKey,
+		"api_key_secret":      t.APIKeySecret,
+		"access_token":        t.AccessToken,
+		"access_token_secret": t.AccessTokenSecret,
+		"user_id":             t.UserID,
+	}
+	// OAuth 2.0 settings are only written once a login has set them.
+	for key, value := range map[string]string{
+		"auth_mode":           t.AuthMode,
+		"client_id":           t.ClientID,
+		"client_secret":       t.ClientSecret,
+		"oauth2_access_token": t.OAuth2AccessToken,
+		"refresh_token":       t.RefreshToken,
+		"expires_at":          t.ExpiresAt,
+		"scopes":              t.Scopes,
+	} {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+func (l LinkedInConfig) settings() map[string]any {
+	settings := map[string]any{
+		"access_token":     l.AccessToken,
+		"person_urn":       l.PersonURN,
+		"organization_urn": l.OrganizationURN,
+	}
+	// OAuth settings are only written once a login has set them.
+	for key, value := range map[string]string{
+		"client_id":                l.ClientID,
+		"client_secret":            l.ClientSecret,
+		"refresh_token":            l.RefreshToken,
+		"expires_at":               l.ExpiresAt,
+		"refresh_token_expires_at": l.RefreshTokenExpiresAt,
+		"scopes":                   l.Scopes,
+	} {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	return settings
+}
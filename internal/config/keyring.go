@@ -0,0 +1,317 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	keyringService = "socials"
+
+	// secretsFile holds the encrypted fallback store, for machines without
+	// a keyring service such as CI containers.
+	secretsFile = "secrets.enc"
+
+	pbkdf2Iterations = 600000
+)
+
+// KeyringBackend reports where keyring: secrets are kept. SOCIALS_KEYRING
+// picks "system" or "file" explicitly; otherwise the encrypted file is used
+// when SOCIALS_KEYRING_PASSPHRASE is set, and the system keyring if not.
+func KeyringBackend() (string, error) {
+	switch backend := os.Getenv("SOCIALS_KEYRING"); backend {
+	case "system", "file":
+		return backend, nil
+	case "":
+		if os.Getenv("SOCIALS_KEYRING_PASSPHRASE") != "" {
+			return "file", nil
+		}
+		return "system", nil
+	default:
+		return "", fmt.Errorf("unknown SOCIALS_KEYRING backend %q (use 'system' or 'file')", backend)
+	}
+}
+
+// GetKeyring returns the secret stored under account.
+func GetKeyring(account string) (string, error) {
+	backend, err := KeyringBackend()
+	if err != nil {
+		return "", err
+	}
+
+	if backend == "system" {
+		secret, err := keyring.Get(keyringService, account)
+		if errors.Is(err, keyring.ErrNotFound) {
+			return "", fmt.Errorf("no secret %q in the system keyring", account)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read system keyring: %w", err)
+		}
+		return secret, nil
+	}
+
+	secrets, _, err := readSecretsFile()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[account]
+	if !ok {
+		return "", fmt.Errorf("no secret %q in %s", account, secretsFile)
+	}
+	return secret, nil
+}
+
+// SetKeyring stores secret under account and returns the keyring:
+// reference to put in the config file instead.
+func SetKeyring(account, secret string) (string, error) {
+	backend, err := KeyringBackend()
+	if err != nil {
+		return "", err
+	}
+
+	if backend == "system" {
+		if err := keyring.Set(keyringService, account, secret); err != nil {
+			return "", fmt.Errorf("failed to write system keyring: %w", err)
+		}
+		return "keyring:" + account, nil
+	}
+
+	secrets, salt, err := readSecretsFile()
+	if err != nil {
+		return "", err
+	}
+	secrets[account] = secret
+	if err := writeSecretsFile(secrets, salt); err != nil {
+		return "", err
+	}
+	return "keyring:" + account, nil
+}
+
+// MigrateSecrets moves every secret stored in plain text in cfg to the
+// keyring, replacing it with a reference. It returns the keys it moved.
+func MigrateSecrets(cfg *Config) ([]string, error) {
+	var moved []string
+	migrate := func(profile string, accounts *Config) error {
+		fields := accounts.secretFields()
+		for _, key := range slices.Sorted(maps.Keys(fields)) {
+			field := fields[key]
+			if *field == "" || IsReference(*field) {
+				continue
+			}
+			ref, err := SetKeyring(profile+"/"+key, *field)
+			if err != nil {
+				return fmt.Errorf("failed to migrate %s: %w", key, err)
+			}
+			*field = ref
+			moved = append(moved, ProfileKey(profile, key))
+		}
+		return nil
+	}
+
+	if err := migrate(DefaultProfile, cfg); err != nil {
+		return moved, err
+	}
+	for _, name := range cfg.ProfileNames()[1:] {
+		p := cfg.Profiles[name]
+		accounts := &Config{Twitter: p.Twitter, LinkedIn: p.LinkedIn}
+		err := migrate(name, accounts)
+		cfg.Profiles[name] = Profile{Twitter: accounts.Twitter, LinkedIn: accounts.LinkedIn}
+		if err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
+
+type encryptedSecrets struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+var (
+	derivedKeyMu sync.Mutex
+	derivedKeys  = map[string][]byte{}
+)
+
+// secretsKey derives the file's AES key from the passphrase. Derivation
+// is deliberately slow, so keys are remembered for the process.
+func secretsKey(salt []byte) ([]byte, error) {
+	passphrase := os.Getenv("SOCIALS_KEYRING_PASSPHRASE")
+	if passphrase == "" {
+		return nil, fmt.Errorf("SOCIALS_KEYRING_PASSPHRASE is required for the file keyring")
+	}
+
+	derivedKeyMu.Lock()
+	defer derivedKeyMu.Unlock()
+
+	cacheKey := passphrase + "\x00" + string(salt)
+	if key, ok := derivedKeys[cacheKey]; ok {
+		return key, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	derivedKeys[cacheKey] = key
+	return key, nil
+}
+
+func secretsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, secretsFile), nil
+}
+
+// readSecretsFile decrypts the secrets file, returning its salt so writes
+// can keep the derived key.
+func readSecretsFile() (map[string]string, []byte, error) {
+	path, err := secretsPath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", secretsFile, err)
+	}
+
+	var enc encryptedSecrets
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", secretsFile, err)
+	}
+
+	key, err := secretsKey(enc.Salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	plain, err := gcm.Open(nil, enc.Nonce, enc.Data, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt %s: wrong SOCIALS_KEYRING_PASSPHRASE?", secretsFile)
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", secretsFile, err)
+	}
+	return secrets, enc.Salt, nil
+}
+
+// writeSecretsFile encrypts secrets under a fresh nonce. A nil salt starts
+// a new file with a random one.
+func writeSecretsFile(secrets map[string]string, salt []byte) error {
+	path, err := secretsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+
+	enc := encryptedSecrets{
+		Salt:  salt,
+		Nonce: make([]byte, 12),
+	}
+	if enc.Salt == nil {
+		enc.Salt = make([]byte, 16)
+		rand.Read(enc.Salt)
+	}
+	rand.Read(enc.Nonce)
+
+	key, err := secretsKey(enc.Salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	enc.Data = gcm.Seal(nil, enc.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(enc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", secretsFile, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), secretsFile+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", secretsFile, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", secretsFile, err)
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", secretsFile, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", secretsFile, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", secretsFile, err)
+	}
+	return nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// IsReference reports whether value already points at a secret rather
+// than holding one.
+func IsReference(value string) bool {
+	scheme, _, ok := strings.Cut(value, ":")
+	if !ok {
+		return false
+	}
+	switch scheme {
+	case "env", "file", "cmd", "keyring":
+		return true
+	}
+	return false
+}
+
+// IsFixedReference reports whether value points at a secret socials can
+// read but not write: env:, file: and cmd: references.
+func IsFixedReference(value string) bool {
+	return IsReference(value) && !strings.HasPrefix(value, "keyring:")
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hev/socials/internal/auth"
+)
+
+// ResolveSecret returns the value a config setting refers to. Settings can
+// hold a reference instead of the secret itself:
+//
+//	env:NAME      the NAME environment variable
+//	file:PATH     the contents of PATH, e.g. /run/secrets/x
+//	cmd:COMMAND   the output of COMMAND run by sh, e.g. "pass show x"
+//	keyring:NAME  the secret stored as NAME, see GetKeyring
+//
+// Anything else is returned unchanged. Trailing newlines are trimmed from
+// files and command output.
+func ResolveSecret(value string) (string, error) {
+	scheme, ref, ok := strings.Cut(value, ":")
+	if !ok {
+		return value, nil
+	}
+
+	switch scheme {
+	case "env":
+		secret, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", ref)
+		}
+		return secret, nil
+
+	case "file":
+		path := ref
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to get home directory: %w", err)
+			}
+			path = filepath.Join(home, rest)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+
+	case "cmd":
+		var stderr bytes.Buffer
+		c := exec.Command("sh", "-c", ref)
+		c.Stderr = &stderr
+		out, err := c.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("secret command %q failed: %s", ref, msg)
+			}
+			return "", fmt.Errorf("secret command %q failed: %w", ref, err)
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+
+	case "keyring":
+		return GetKeyring(ref)
+
+	default:
+		// Not a reference, e.g. a URN like urn:li:person:abc.
+		return value, nil
+	}
+}
+
+// Resolve replaces every reference in the Twitter account with the secret
+// it points to. Commands resolve an account only when they build a client
+// for it, so a broken reference doesn't get in the way of other networks.
+func (t *TwitterConfig) Resolve() error {
+	if t.resolved {
+		return nil
+	}
+	if err := resolveFields(t.fields()); err != nil {
+		return err
+	}
+	t.resolved = true
+	return nil
+}
+
+// Resolve replaces every reference in the LinkedIn account with the secret
+// it points to, like TwitterConfig.Resolve.
+func (l *LinkedInConfig) Resolve() error {
+	if l.resolved {
+		return nil
+	}
+	if err := resolveFields(l.fields()); err != nil {
+		return err
+	}
+	l.resolved = true
+	return nil
+}
+
+func resolveFields(fields map[string]*string) error {
+	for name, field := range fields {
+		resolved, err := ResolveSecret(*field)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		*field = resolved
+	}
+	return nil
+}
+
+// secretFields returns the settings that are credentials, by config key.
+func (c *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"twitter.api_key":             &c.Twitter.APIKey,
+		"twitter.api_key_secret":      &c.Twitter.APIKeySecret,
+		"twitter.access_token":        &c.Twitter.AccessToken,
+		"twitter.access_token_secret": &c.Twitter.AccessTokenSecret,
+		"twitter.client_secret":       &c.Twitter.ClientSecret,
+		"twitter.oauth2_access_token": &c.Twitter.OAuth2AccessToken,
+		"twitter.refresh_token":       &c.Twitter.RefreshToken,
+		"linkedin.access_token":       &c.LinkedIn.AccessToken,
+		"linkedin.client_secret":      &c.LinkedIn.ClientSecret,
+		"linkedin.refresh_token":      &c.LinkedIn.RefreshToken,
+	}
+}
+
+// fields returns the account settings that may hold references, by config
+// key.
+func (t *TwitterConfig) fields() map[string]*string {
+	return map[string]*string{
+		"twitter.api_key":             &t.APIKey,
+		"twitter.api_key_secret":      &t.APIKeySecret,
+		"twitter.access_token":        &t.AccessToken,
+		"twitter.access_token_secret": &t.AccessTokenSecret,
+		"twitter.user_id":             &t.UserID,
+		"twitter.client_id":           &t.ClientID,
+		"twitter.client_secret":       &t.ClientSecret,
+		"twitter.oauth2_access_token": &t.OAuth2AccessToken,
+		"twitter.refresh_token":       &t.RefreshToken,
+	}
+}
+
+func (l *LinkedInConfig) fields() map[string]*string {
+	return map[string]*string{
+		"linkedin.access_token":     &l.AccessToken,
+		"linkedin.person_urn":       &l.PersonURN,
+		"linkedin.organization_urn": &l.OrganizationURN,
+		"linkedin.client_id":        &l.ClientID,
+		"linkedin.client_secret":    &l.ClientSecret,
+		"linkedin.refresh_token":    &l.RefreshToken,
+	}
+}
+
+// StoreSecret returns what to write to the config file for a new secret.
+// Settings that already point at the keyring, or all of them with
+// useKeyring, keep the secret there under account; others hold it as is.
+func StoreSecret(current, account, secret string, useKeyring bool) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	if ref, ok := strings.CutPrefix(current, "keyring:"); ok {
+		return SetKeyring(ref, secret)
+	}
+	if useKeyring {
+		return SetKeyring(account, secret)
+	}
+	return secret, nil
+}
+
+// SaveRefreshedToken writes a refreshed OAuth 2.0 token for network to the
+// named profile in the config file. Tokens kept in the keyring stay there;
+// ones set by env:, file: or cmd: reference can't be written back, which is
+// reported as an error after saving everything else.
+func SaveRefreshedToken(profile, network string, token *auth.Token) error {
+	raw, err := LoadRaw()
+	if err != nil {
+		return err
+	}
+	p := raw.GetProfile(profile)
+
+	var skipped []string
+	update := func(setting *string, key, secret string) error {
+		if IsFixedReference(*setting) {
+			skipped = append(skipped, key)
+			return nil
+		}
+		value, err := StoreSecret(*setting, profile+"/"+key, secret, false)
+		if err != nil {
+			return err
+		}
+		*setting = value
+		return nil
+	}
+
+	switch network {
+	case "twitter":
+		if err := update(&p.Twitter.OAuth2AccessToken, "twitter.oauth2_access_token", token.AccessToken); err != nil {
+			return err
+		}
+		if err := update(&p.Twitter.RefreshToken, "twitter.refresh_token", token.RefreshToken); err != nil {
+			return err
+		}
+		p.Twitter.ExpiresAt = auth.FormatTime(token.ExpiresAt)
+	case "linkedin":
+		if err := update(&p.LinkedIn.AccessToken, "linkedin.access_token", token.AccessToken); err != nil {
+			return err
+		}
+		if err := update(&p.LinkedIn.RefreshToken, "linkedin.refresh_token", token.RefreshToken); err != nil {
+			return err
+		}
+		p.LinkedIn.ExpiresAt = auth.FormatTime(token.ExpiresAt)
+		if !token.RefreshTokenExpiresAt.IsZero() {
+			p.LinkedIn.RefreshTokenExpiresAt = auth.FormatTime(token.RefreshTokenExpiresAt)
+		}
+	default:
+		return fmt.Errorf("unknown network: %s", network)
+	}
+
+	raw.SetProfile(profile, p)
+	if err := Save(raw); err != nil {
+		return err
+	}
+	if len(skipped) > 0 {
+		return fmt.Errorf("%s set by reference, update it with the new token", strings.Join(skipped, " and "))
+	}
+	return nil
+}
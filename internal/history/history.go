@@ -0,0 +1,83 @@
+package history
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hev/socials/internal/state"
+)
+
+const historyFile = "history.json"
+
+// Entry is one post as published by socials. Threads are recorded as a
+// single entry with their IDs in posting order.
+type Entry struct {
+	Network  string   `json:"network"`
+	IDs      []string `json:"ids"`
+	PostedAt string   `json:"posted_at"`
+}
+
+func Load() ([]Entry, error) {
+	var entries []Entry
+	if err := state.ReadJSON(historyFile, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func Record(network string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	entries, err := Load()
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, Entry{
+		Network:  network,
+		IDs:      ids,
+		PostedAt: time.Now().Format(time.RFC3339),
+	})
+	return state.WriteJSON(historyFile, entries)
+}
+
+// FindThread returns the recorded entry containing id, or nil if socials
+// has no record of posting it.
+func FindThread(network, id string) (*Entry, error) {
+	entries, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if entries[i].Network == network && slices.Contains(entries[i].IDs, id) {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Forget drops deleted IDs from the history, removing entries that no
+// longer have any IDs left.
+func Forget(network string, ids []string) error {
+	entries, err := Load()
+	if err != nil {
+		return err
+	}
+
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Network == network {
+			e.IDs = slices.DeleteFunc(e.IDs, func(id string) bool {
+				return slices.Contains(ids, id)
+			})
+			if len(e.IDs) == 0 {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+	return state.WriteJSON(historyFile, kept)
+}
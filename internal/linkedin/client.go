@@ -1,62 +1,142 @@
 package linkedin
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/hev/socials/internal/auth"
 	"github.com/hev/socials/internal/config"
 )
 
 const baseURL = "https://api.linkedin.com"
 
 type Client struct {
-	httpClient  *http.Client
-	accessToken string
-	personURN   string
+	httpClient      *http.Client
+	accessToken     string
+	personURN       string
+	organizationURN string
+
+	// author is who posts, comments and feeds act as. It defaults to the
+	// member's own profile.
+	author string
+
+	// refresher renews the access token; nil without a refresh token.
+	refresher *auth.Refresher
+
+	// log reports problems that don't fail the request; nil ignores them.
+	log func(format string, args ...any)
+
+	// clockSkew comes from the most recent response's Date header.
+	clockSkew  time.Duration
+	clockKnown bool
 }
 
 func NewClient(cfg *config.LinkedInConfig) *Client {
-	return &Client{
-		httpClient:  &http.Client{},
-		accessToken: cfg.AccessToken,
-		personURN:   cfg.PersonURN,
+	c := &Client{
+		httpClient:      &http.Client{},
+		accessToken:     cfg.AccessToken,
+		personURN:       cfg.PersonURN,
+		organizationURN: cfg.OrganizationURN,
+		author:          cfg.PersonURN,
+		log:             cfg.Logf,
 	}
+	if cfg.RefreshToken != "" && cfg.ClientID != "" {
+		c.refresher = &auth.Refresher{
+			Endpoint:     OAuthEndpoint(cfg.ClientID, cfg.ClientSecret),
+			RefreshToken: cfg.RefreshToken,
+			ExpiresAt:    auth.ParseTime(cfg.ExpiresAt),
+			Save:         cfg.SaveToken,
+		}
+	}
+	return c
+}
+
+// ActAs switches the author used for posting and reading posts. as is
+// "person" (the default) or "org" for the configured organization page; a
+// full urn:li:organization URN is also accepted.
+func (c *Client) ActAs(as string) error {
+	switch {
+	case as == "" || as == "person" || as == "me":
+		c.author = c.personURN
+	case as == "org" || as == "organization":
+		if c.organizationURN == "" {
+			return fmt.Errorf("no organization configured, run 'socials config set linkedin.organization_urn urn:li:organization:<id>'")
+		}
+		c.author = c.organizationURN
+	case strings.HasPrefix(as, "urn:li:organization:"):
+		c.author = as
+	default:
+		return fmt.Errorf("unknown author %q (use 'person' or 'org')", as)
+	}
+	return nil
 }
 
 func (c *Client) doRequest(method, url string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(method, url, body)
+	return c.doRequestWithHeaders(method, url, body, nil)
+}
+
+// doRequestWithHeaders is doRequest with extra request headers, e.g. the
+// X-RestLi-Method override used for partial updates.
+func (c *Client) doRequestWithHeaders(method, url string, body io.Reader, headers map[string]string) ([]byte, error) {
+	_, data, err := c.request(method, url, body, headers)
+	return data, err
+}
+
+// create POSTs a new entity and returns the response body and the new
+// entity's ID, which LinkedIn sends in the x-restli-id header.
+func (c *Client) create(url string, body io.Reader) ([]byte, string, error) {
+	header, data, err := c.request("POST", url, body, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, "", err
 	}
+	return data, header.Get("x-restli-id"), nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
-	req.Header.Set("LinkedIn-Version", "202602")
-	req.Header.Set("X-Restli-Protocol-Version", "2.0.0")
+// request sends an API request and returns the response headers and body.
+// Expired access tokens are refreshed first when the config has a refresh
+// token, and a 401 triggers one refresh and retry, so the body is buffered.
+func (c *Client) request(method, url string, body io.Reader, headers map[string]string) (http.Header, []byte, error) {
+	var payload []byte
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, nil, fmt.Errorf("failed to read request body: %w", err)
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	refreshed := false
+	if c.refresher != nil && c.refresher.Expired() {
+		// On failure, try the old token anyway; a 401 explains the rest.
+		refreshed = c.refresh() == nil
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	resp, data, err := c.send(method, url, payload, headers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, err
+	}
+
+	if resp.StatusCode == 401 && c.refresher != nil && !refreshed {
+		if err := c.refresh(); err != nil {
+			return nil, nil, fmt.Errorf("authentication failed (401) and %w", err)
+		}
+		if resp, data, err = c.send(method, url, payload, headers); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	if resp.StatusCode == 401 {
-		return nil, fmt.Errorf("authentication failed (401): check your LinkedIn access token")
+		return nil, nil, fmt.Errorf("authentication failed (401): check your LinkedIn access token or run 'socials auth login linkedin'")
 	}
 	if resp.StatusCode == 403 {
-		return nil, fmt.Errorf("forbidden (403): check your LinkedIn API permissions")
+		return nil, nil, fmt.Errorf("forbidden (403): check your LinkedIn API permissions")
 	}
 	if resp.StatusCode == 429 {
-		return nil, fmt.Errorf("rate limited (429): too many requests, try again later")
+		return nil, nil, &RateLimitError{Reset: retryAfter(resp.Header)}
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		var apiErr struct {
@@ -64,10 +144,78 @@ func (c *Client) doRequest(method, url string, body io.Reader) ([]byte, error) {
 			Status  int    `json:"status"`
 		}
 		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
-			return nil, fmt.Errorf("linkedin API error (%d): %s", resp.StatusCode, apiErr.Message)
+			return nil, nil, fmt.Errorf("linkedin API error (%d): %s", resp.StatusCode, apiErr.Message)
 		}
-		return nil, fmt.Errorf("linkedin API error (%d): %s", resp.StatusCode, string(data))
+		return nil, nil, fmt.Errorf("linkedin API error (%d): %s", resp.StatusCode, string(data))
 	}
 
-	return data, nil
+	return resp.Header, data, nil
+}
+
+func (c *Client) send(method, url string, payload []byte, headers map[string]string) (*http.Response, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("LinkedIn-Version", "202602")
+	req.Header.Set("X-Restli-Protocol-Version", "2.0.0")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if skew, ok := auth.ClockSkew(resp.Header); ok {
+		c.clockSkew, c.clockKnown = skew, true
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp, data, nil
+}
+
+// ClockSkew returns how far LinkedIn's clock is ahead of the local one, as
+// of the last response. ok is false before any response.
+func (c *Client) ClockSkew() (skew time.Duration, ok bool) {
+	return c.clockSkew, c.clockKnown
+}
+
+func (c *Client) logf(format string, args ...any) {
+	if c.log != nil {
+		c.log(format, args...)
+	}
+}
+
+// TokenExpiry returns when the access token expires, taking refreshes made
+// by the client into account. It is zero when unknown or without a refresh
+// token.
+func (c *Client) TokenExpiry() time.Time {
+	if c.refresher == nil {
+		return time.Time{}
+	}
+	return c.refresher.Expiry()
+}
+
+func (c *Client) refresh() error {
+	token, err := c.refresher.Refresh()
+	if err != nil {
+		return err
+	}
+	c.accessToken = token
+	return nil
 }
@@ -0,0 +1,131 @@
+package linkedin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hev/socials/internal/output"
+)
+
+type comment struct {
+	ID      string `json:"id"`
+	URN     string `json:"$URN"`
+	Actor   string `json:"actor"`
+	Object  string `json:"object"`
+	Created struct {
+		Time int64 `json:"time"`
+	} `json:"created"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+	LikesSummary struct {
+		TotalLikes int `json:"totalLikes"`
+	} `json:"likesSummary"`
+}
+
+type commentsResponse struct {
+	Elements []comment `json:"elements"`
+}
+
+type createCommentRequest struct {
+	Actor   string `json:"actor"`
+	Object  string `json:"object"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+func (c *Client) GetComments(postURN string, count int) ([]output.LinkedInComment, error) {
+	if count <= 0 {
+		count = 10
+	}
+
+	reqURL := fmt.Sprintf("%s/rest/socialActions/%s/comments?count=%d", baseURL, url.PathEscape(postURN), count)
+
+	data, err := c.doRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	var resp commentsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse comments: %w", err)
+	}
+
+	actors := make([]string, 0, len(resp.Elements))
+	for _, cm := range resp.Elements {
+		actors = append(actors, cm.Actor)
+	}
+	names := c.resolveNames(actors)
+
+	comments := make([]output.LinkedInComment, 0, len(resp.Elements))
+	for _, cm := range resp.Elements {
+		result := toComment(postURN, cm)
+		result.AuthorName = nameOf(names, cm.Actor)
+		comments = append(comments, result)
+	}
+
+	return comments, nil
+}
+
+// CreateComment comments on a post as the client's current author.
+func (c *Client) CreateComment(postURN, text string) (*output.LinkedInComment, error) {
+	reqBody := createCommentRequest{
+		Actor:  c.author,
+		Object: postURN,
+	}
+	reqBody.Message.Text = text
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/rest/socialActions/%s/comments", baseURL, url.PathEscape(postURN))
+
+	data, id, err := c.create(reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	// The comment comes back in the body; without one, the header's ID
+	// is all there is and the rest is what was sent.
+	var resp comment
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse comment: %w", err)
+		}
+	} else {
+		resp.Actor = c.author
+		resp.Message.Text = text
+		resp.Created.Time = time.Now().UnixMilli()
+	}
+	if resp.URN == "" && resp.ID == "" {
+		resp.ID = id
+	}
+	if resp.ID == "" && resp.URN == "" {
+		return nil, fmt.Errorf("comment was created but LinkedIn returned no ID")
+	}
+
+	result := toComment(postURN, resp)
+	result.AuthorName = nameOf(c.resolveNames([]string{resp.Actor}), resp.Actor)
+	return &result, nil
+}
+
+func toComment(postURN string, cm comment) output.LinkedInComment {
+	id := cm.URN
+	if id == "" {
+		id = cm.ID
+	}
+	return output.LinkedInComment{
+		ID:        id,
+		PostURN:   postURN,
+		Text:      cm.Message.Text,
+		AuthorURN: cm.Actor,
+		CreatedAt: time.UnixMilli(cm.Created.Time).Format(time.RFC3339),
+		Likes:     cm.LikesSummary.TotalLikes,
+	}
+}
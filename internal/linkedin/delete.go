@@ -0,0 +1,22 @@
+package linkedin
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hev/socials/internal/output"
+)
+
+func (c *Client) DeletePost(urn string) (*output.DeleteResult, error) {
+	reqURL := fmt.Sprintf("%s/rest/posts/%s", baseURL, url.PathEscape(urn))
+
+	if _, err := c.doRequest("DELETE", reqURL, nil); err != nil {
+		return nil, fmt.Errorf("failed to delete post %s: %w", urn, err)
+	}
+
+	return &output.DeleteResult{
+		Network: "linkedin",
+		ID:      urn,
+		Deleted: true,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package linkedin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type getPostResponse struct {
+	ID         string `json:"id"`
+	Commentary string `json:"commentary"`
+}
+
+type partialUpdateRequest struct {
+	Patch struct {
+		Set map[string]any `json:"$set"`
+	} `json:"patch"`
+}
+
+// GetCommentary returns the current commentary of a published post.
+func (c *Client) GetCommentary(urn string) (string, error) {
+	reqURL := fmt.Sprintf("%s/rest/posts/%s", baseURL, url.PathEscape(urn))
+
+	data, err := c.doRequest("GET", reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get post %s: %w", urn, err)
+	}
+
+	var resp getPostResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse post: %w", err)
+	}
+	return resp.Commentary, nil
+}
+
+// UpdateCommentary replaces the commentary of a published post using a
+// Rest.li PARTIAL_UPDATE, leaving every other field untouched.
+func (c *Client) UpdateCommentary(urn, text string) error {
+	var reqBody partialUpdateRequest
+	reqBody.Patch.Set = map[string]any{"commentary": text}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal update: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/rest/posts/%s", baseURL, url.PathEscape(urn))
+	headers := map[string]string{"X-RestLi-Method": "PARTIAL_UPDATE"}
+
+	if _, err := c.doRequestWithHeaders("POST", reqURL, bytes.NewReader(body), headers); err != nil {
+		return fmt.Errorf("failed to update post %s: %w", urn, err)
+	}
+	return nil
+}
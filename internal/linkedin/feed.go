@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/hev/socials/internal/output"
@@ -11,12 +12,12 @@ import (
 
 type postsResponse struct {
 	Elements []struct {
-		ID           string `json:"id"`
-		Commentary   string `json:"commentary"`
-		Author       string `json:"author"`
-		CreatedAt    int64  `json:"createdAt"`
+		ID             string `json:"id"`
+		Commentary     string `json:"commentary"`
+		Author         string `json:"author"`
+		CreatedAt      int64  `json:"createdAt"`
 		LifecycleState string `json:"lifecycleState"`
-		Distribution struct {
+		Distribution   struct {
 			FeedDistribution string `json:"feedDistribution"`
 		} `json:"distribution"`
 		SocialDetail *struct {
@@ -26,51 +27,128 @@ type postsResponse struct {
 			} `json:"totalSocialActivityCounts"`
 		} `json:"socialDetail,omitempty"`
 	} `json:"elements"`
+	Paging struct {
+		Start int `json:"start"`
+		Count int `json:"count"`
+		Total int `json:"total"`
+	} `json:"paging"`
 }
 
-func (c *Client) GetPosts(count int) ([]output.LinkedInPost, error) {
+// PageOptions selects which posts to fetch. LinkedIn pages by offset, so
+// Cursor is the start index of the next page. Since and Until are applied
+// to each post's creation time.
+type PageOptions struct {
+	Count  int
+	Limit  int
+	All    bool
+	Cursor string
+
+	Since time.Time
+	Until time.Time
+}
+
+func (c *Client) GetPosts(opts PageOptions) (*output.LinkedInPostPage, error) {
+	count := opts.Count
 	if count <= 0 {
 		count = 10
 	}
 
-	params := url.Values{}
-	params.Set("author", c.personURN)
-	params.Set("q", "author")
-	params.Set("count", fmt.Sprintf("%d", count))
-	params.Set("sortBy", "LAST_MODIFIED")
+	start := 0
+	if opts.Cursor != "" {
+		var err error
+		start, err = strconv.Atoi(opts.Cursor)
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid linkedin cursor %q", opts.Cursor)
+		}
+	}
 
-	reqURL := fmt.Sprintf("%s/rest/posts?%s", baseURL, params.Encode())
+	page := &output.LinkedInPostPage{Posts: []output.LinkedInPost{}}
+	// offsets holds each post's position in the full list, for a cursor
+	// that resumes in the middle of a page.
+	var offsets []int
 
-	data, err := c.doRequest("GET", reqURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get posts: %w", err)
-	}
+	for {
+		params := url.Values{}
+		params.Set("author", c.author)
+		params.Set("q", "author")
+		params.Set("start", strconv.Itoa(start))
+		params.Set("count", strconv.Itoa(count))
+		params.Set("sortBy", "CREATED")
 
-	var resp postsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse posts: %w", err)
-	}
+		reqURL := fmt.Sprintf("%s/rest/posts?%s", baseURL, params.Encode())
+
+		data, err := c.doRequest("GET", reqURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get posts: %w", err)
+		}
 
-	posts := make([]output.LinkedInPost, 0, len(resp.Elements))
-	for _, p := range resp.Elements {
-		var likes, comments int
-		if p.SocialDetail != nil {
-			likes = p.SocialDetail.TotalSocialActivityCounts.NumLikes
-			comments = p.SocialDetail.TotalSocialActivityCounts.NumComments
+		var resp postsResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse posts: %w", err)
 		}
 
-		createdAt := time.UnixMilli(p.CreatedAt).Format(time.RFC3339)
-
-		posts = append(posts, output.LinkedInPost{
-			ID:         p.ID,
-			Text:       p.Commentary,
-			AuthorURN:  p.Author,
-			AuthorName: "You",
-			CreatedAt:  createdAt,
-			Likes:      likes,
-			Comments:   comments,
-		})
+		olderThanSince := 0
+		for i, p := range resp.Elements {
+			created := time.UnixMilli(p.CreatedAt)
+			if !opts.Since.IsZero() && created.Before(opts.Since) {
+				olderThanSince++
+				continue
+			}
+			if !opts.Until.IsZero() && !created.Before(opts.Until) {
+				continue
+			}
+
+			var likes, comments int
+			if p.SocialDetail != nil {
+				likes = p.SocialDetail.TotalSocialActivityCounts.NumLikes
+				comments = p.SocialDetail.TotalSocialActivityCounts.NumComments
+			}
+
+			page.Posts = append(page.Posts, output.LinkedInPost{
+				ID:        p.ID,
+				Text:      p.Commentary,
+				AuthorURN: p.Author,
+				CreatedAt: created.Format(time.RFC3339),
+				Likes:     likes,
+				Comments:  comments,
+			})
+			offsets = append(offsets, start+i)
+		}
+
+		start += len(resp.Elements)
+		more := len(resp.Elements) == count && (resp.Paging.Total == 0 || start < resp.Paging.Total)
+		// Posts come newest first, so a page entirely older than Since
+		// means there is nothing left to find.
+		if olderThanSince > 0 && olderThanSince == len(resp.Elements) {
+			more = false
+		}
+
+		page.NextCursor = ""
+		if !more {
+			break
+		}
+		page.NextCursor = strconv.Itoa(start)
+		if opts.Limit > 0 && len(page.Posts) >= opts.Limit {
+			break
+		}
+		if opts.Limit <= 0 && !opts.All {
+			break
+		}
+	}
+
+	if opts.Limit > 0 && len(page.Posts) > opts.Limit {
+		page.Posts = page.Posts[:opts.Limit]
+		page.NextCursor = strconv.Itoa(offsets[opts.Limit])
+	}
+
+	authors := make([]string, 0, len(page.Posts))
+	for _, p := range page.Posts {
+		authors = append(authors, p.AuthorURN)
+	}
+	names := c.resolveNames(authors)
+	for i := range page.Posts {
+		page.Posts[i].AuthorName = nameOf(names, page.Posts[i].AuthorURN)
 	}
 
-	return posts, nil
+	return page, nil
 }
@@ -0,0 +1,73 @@
+package linkedin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+type initializeUploadRequest struct {
+	InitializeUploadRequest struct {
+		Owner string `json:"owner"`
+	} `json:"initializeUploadRequest"`
+}
+
+type initializeUploadResponse struct {
+	Value struct {
+		UploadURL string `json:"uploadUrl"`
+		Image     string `json:"image"`
+	} `json:"value"`
+}
+
+// UploadImage uploads a local image owned by the current author and
+// returns its image URN for use in post content.
+func (c *Client) UploadImage(path string) (string, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	var reqBody initializeUploadRequest
+	reqBody.InitializeUploadRequest.Owner = c.author
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal upload request: %w", err)
+	}
+
+	data, err := c.doRequest("POST", baseURL+"/rest/images?action=initializeUpload", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize image upload: %w", err)
+	}
+
+	var resp initializeUploadResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse upload response: %w", err)
+	}
+	if resp.Value.UploadURL == "" || resp.Value.Image == "" {
+		return "", fmt.Errorf("image upload was not initialized: %s", string(data))
+	}
+
+	req, err := http.NewRequest("PUT", resp.Value.UploadURL, bytes.NewReader(file))
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	uploadResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("image upload failed: %w", err)
+	}
+	defer uploadResp.Body.Close()
+
+	if uploadResp.StatusCode < 200 || uploadResp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(uploadResp.Body)
+		return "", fmt.Errorf("image upload failed (%d): %s", uploadResp.StatusCode, string(msg))
+	}
+
+	return resp.Value.Image, nil
+}
@@ -1,35 +1,127 @@
 package linkedin
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/hev/socials/internal/output"
 )
 
+type messagingMember struct {
+	Member string `json:"com.linkedin.voyager.messaging.MessagingMember"`
+}
+
+type conversationElement struct {
+	ID           string            `json:"id"`
+	Participants []messagingMember `json:"participants"`
+	Events       []struct {
+		EntityURN    string `json:"entityUrn"`
+		EventContent struct {
+			MessageEvent struct {
+				Body string `json:"body"`
+			} `json:"messageEvent"`
+		} `json:"eventContent"`
+		From      messagingMember `json:"from"`
+		CreatedAt int64           `json:"createdAt"`
+	} `json:"events"`
+}
+
 type conversationsResponse struct {
-	Elements []struct {
-		ID    string `json:"id"`
-		Events []struct {
-			EventContent struct {
-				MessageEvent struct {
-					Body string `json:"body"`
-				} `json:"messageEvent"`
-			} `json:"eventContent"`
-			From struct {
-				Member string `json:"com.linkedin.voyager.messaging.MessagingMember"`
-			} `json:"from"`
-			CreatedAt int64 `json:"createdAt"`
-		} `json:"events"`
-	} `json:"elements"`
+	Elements []conversationElement `json:"elements"`
 }
 
+// GetMessages returns the most recent count messages across all
+// conversations, newest first.
 func (c *Client) GetMessages(count int) ([]output.LinkedInMessage, error) {
 	if count <= 0 {
 		count = 10
 	}
 
+	elements, err := c.getConversations(count)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []output.LinkedInMessage
+	for _, conv := range elements {
+		messages = append(messages, conv.messages()...)
+	}
+	slices.SortStableFunc(messages, func(a, b output.LinkedInMessage) int {
+		return cmp.Compare(b.CreatedAt, a.CreatedAt)
+	})
+
+	if len(messages) > count {
+		messages = messages[:count]
+	}
+
+	senders := make([]string, 0, len(messages))
+	for _, m := range messages {
+		senders = append(senders, m.SenderURN)
+	}
+	names := c.resolveNames(senders)
+	for i := range messages {
+		messages[i].SenderName = nameOf(names, messages[i].SenderURN)
+	}
+
+	return messages, nil
+}
+
+// GetConversations returns up to count conversations with their messages.
+func (c *Client) GetConversations(count int) ([]output.Conversation, error) {
+	if count <= 0 {
+		count = 10
+	}
+
+	elements, err := c.getConversations(count)
+	if err != nil {
+		return nil, err
+	}
+
+	names := c.resolveNames(memberURNs(elements...))
+	conversations := make([]output.Conversation, 0, len(elements))
+	for _, conv := range elements {
+		conversations = append(conversations, conv.conversation(names))
+	}
+	slices.SortStableFunc(conversations, func(a, b output.Conversation) int {
+		return cmp.Compare(b.LastMessageAt, a.LastMessageAt)
+	})
+	return conversations, nil
+}
+
+// GetConversation returns the last count messages of one conversation.
+func (c *Client) GetConversation(conversationID string, count int) (*output.Conversation, error) {
+	if count <= 0 {
+		count = 50
+	}
+
+	reqURL := fmt.Sprintf("%s/rest/conversations/%s", baseURL, url.PathEscape(conversationID))
+
+	data, err := c.doRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation %s: %w", conversationID, err)
+	}
+
+	var conv conversationElement
+	if err := json.Unmarshal(data, &conv); err != nil {
+		return nil, fmt.Errorf("failed to parse conversation: %w", err)
+	}
+	if conv.ID == "" {
+		conv.ID = conversationID
+	}
+
+	result := conv.conversation(c.resolveNames(memberURNs(conv)))
+	if len(result.Messages) > count {
+		result.Messages = result.Messages[len(result.Messages)-count:]
+	}
+	return &result, nil
+}
+
+func (c *Client) getConversations(count int) ([]conversationElement, error) {
 	reqURL := fmt.Sprintf("%s/rest/conversations?q=participant&count=%d", baseURL, count)
 
 	data, err := c.doRequest("GET", reqURL, nil)
@@ -41,24 +133,81 @@ func (c *Client) GetMessages(count int) ([]output.LinkedInMessage, error) {
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse messages: %w", err)
 	}
+	return resp.Elements, nil
+}
 
-	var messages []output.LinkedInMessage
-	for _, conv := range resp.Elements {
+func (conv conversationElement) messages() []output.LinkedInMessage {
+	messages := make([]output.LinkedInMessage, 0, len(conv.Events))
+	for _, event := range conv.Events {
+		// Older responses omit the event URN, so fall back to something
+		// that is still unique within the conversation.
+		id := event.EntityURN
+		if id == "" {
+			id = conv.ID + "/" + strconv.FormatInt(event.CreatedAt, 10)
+		}
+		messages = append(messages, output.LinkedInMessage{
+			ID:             id,
+			ConversationID: conv.ID,
+			Text:           event.EventContent.MessageEvent.Body,
+			SenderURN:      event.From.Member,
+			CreatedAt:      time.UnixMilli(event.CreatedAt).Format(time.RFC3339),
+		})
+	}
+	return messages
+}
+
+// memberURNs returns everyone taking part in the conversations, for
+// resolving their names in one go.
+func memberURNs(elements ...conversationElement) []string {
+	var urns []string
+	for _, conv := range elements {
+		for _, p := range conv.Participants {
+			urns = append(urns, p.Member)
+		}
 		for _, event := range conv.Events {
-			createdAt := time.UnixMilli(event.CreatedAt).Format(time.RFC3339)
-			messages = append(messages, output.LinkedInMessage{
-				ID:         conv.ID,
-				Text:       event.EventContent.MessageEvent.Body,
-				SenderURN:  event.From.Member,
-				SenderName: event.From.Member,
-				CreatedAt:  createdAt,
-			})
+			urns = append(urns, event.From.Member)
 		}
 	}
+	return urns
+}
 
-	if len(messages) > count {
-		messages = messages[:count]
+// conversation returns the conversation with its messages oldest first,
+// naming members from names.
+func (conv conversationElement) conversation(names map[string]string) output.Conversation {
+	result := output.Conversation{
+		Network:      "linkedin",
+		ID:           conv.ID,
+		Participants: []output.Participant{},
+		Messages:     []output.ConversationMessage{},
 	}
 
-	return messages, nil
+	addParticipant := func(urn string) {
+		if urn != "" && !slices.ContainsFunc(result.Participants, func(p output.Participant) bool { return p.ID == urn }) {
+			result.Participants = append(result.Participants, output.Participant{ID: urn, Name: nameOf(names, urn)})
+		}
+	}
+	for _, p := range conv.Participants {
+		addParticipant(p.Member)
+	}
+
+	messages := conv.messages()
+	slices.SortStableFunc(messages, func(a, b output.LinkedInMessage) int {
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	})
+	for _, m := range messages {
+		addParticipant(m.SenderURN)
+		result.Messages = append(result.Messages, output.ConversationMessage{
+			ID:         m.ID,
+			Text:       m.Text,
+			SenderID:   m.SenderURN,
+			SenderName: nameOf(names, m.SenderURN),
+			CreatedAt:  m.CreatedAt,
+		})
+	}
+
+	if n := len(messages); n > 0 {
+		result.LastMessage = output.Preview(messages[n-1].Text)
+		result.LastMessageAt = messages[n-1].CreatedAt
+	}
+	return result
 }
@@ -0,0 +1,187 @@
+package linkedin
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hev/socials/internal/state"
+)
+
+const (
+	namesFile = "linkedin-names.json"
+
+	// namesTTL is how long a resolved name is trusted before it is looked
+	// up again, so renames eventually show up.
+	namesTTL = 30 * 24 * time.Hour
+
+	// namesFailureTTL is how long a URN that couldn't be resolved is left
+	// alone, so polling doesn't repeat failing lookups.
+	namesFailureTTL = 6 * time.Hour
+
+	// namesBatchSize bounds the number of IDs in one batch lookup.
+	namesBatchSize = 50
+)
+
+type cachedName struct {
+	Name       string `json:"name"`
+	ResolvedAt string `json:"resolved_at"`
+	Failed     bool   `json:"failed,omitempty"`
+}
+
+type peopleLookupResponse struct {
+	Results map[string]struct {
+		LocalizedFirstName string `json:"localizedFirstName"`
+		LocalizedLastName  string `json:"localizedLastName"`
+	} `json:"results"`
+}
+
+type organizationsLookupResponse struct {
+	Results map[string]struct {
+		LocalizedName string `json:"localizedName"`
+	} `json:"results"`
+}
+
+// resolveNames maps person and organization URNs to display names. Names
+// are cached in the config dir; the rest are looked up in batches. URNs
+// that can't be resolved are left out, so callers fall back to the URN,
+// and are cached as failures for a while.
+func (c *Client) resolveNames(urns []string) map[string]string {
+	cache := map[string]cachedName{}
+	if err := state.ReadJSON(namesFile, &cache); err != nil {
+		c.logf("Warning: ignoring name cache: %s", err)
+		cache = map[string]cachedName{}
+	}
+
+	names := map[string]string{}
+	var people, orgs []string
+	for _, urn := range urns {
+		if _, ok := names[urn]; ok || urn == "" {
+			continue
+		}
+		if cached, ok := cache[urn]; ok {
+			resolvedAt, err := time.Parse(time.RFC3339, cached.ResolvedAt)
+			switch {
+			case err != nil:
+			case cached.Failed && time.Since(resolvedAt) < namesFailureTTL:
+				continue
+			case !cached.Failed && time.Since(resolvedAt) < namesTTL:
+				names[urn] = cached.Name
+				continue
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(urn, "urn:li:person:"):
+			if !slices.Contains(people, urn) {
+				people = append(people, urn)
+			}
+		case strings.HasPrefix(urn, "urn:li:organization:"):
+			if !slices.Contains(orgs, urn) {
+				orgs = append(orgs, urn)
+			}
+		}
+	}
+
+	resolved := map[string]string{}
+	lookup := func(urns []string, find func([]string) (map[string]string, error)) {
+		for batch := range slices.Chunk(urns, namesBatchSize) {
+			found, err := find(batch)
+			if err != nil {
+				c.logf("Warning: %s", err)
+			}
+			for urn, name := range found {
+				resolved[urn] = name
+			}
+		}
+	}
+	lookup(people, c.lookupPeople)
+	lookup(orgs, c.lookupOrganizations)
+
+	if len(people) > 0 || len(orgs) > 0 {
+		now := time.Now().Format(time.RFC3339)
+		for _, urn := range slices.Concat(people, orgs) {
+			name, ok := resolved[urn]
+			if ok {
+				names[urn] = name
+			}
+			cache[urn] = cachedName{Name: name, ResolvedAt: now, Failed: !ok}
+		}
+		// The cache is only an optimization, so failing to save it is not
+		// worth failing the command over.
+		if err := state.WriteJSON(namesFile, cache); err != nil {
+			c.logf("Warning: %s", err)
+		}
+	}
+
+	// Looking up people usually needs extra permissions, so at least
+	// recognize ourselves.
+	if _, ok := names[c.personURN]; !ok && c.personURN != "" {
+		names[c.personURN] = "You"
+	}
+	return names
+}
+
+func (c *Client) lookupPeople(urns []string) (map[string]string, error) {
+	ids := make([]string, 0, len(urns))
+	for _, urn := range urns {
+		ids = append(ids, "(id:"+strings.TrimPrefix(urn, "urn:li:person:")+")")
+	}
+	reqURL := fmt.Sprintf("%s/v2/people?ids=List(%s)", baseURL, strings.Join(ids, ","))
+
+	data, err := c.doRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up people: %w", err)
+	}
+
+	var resp peopleLookupResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse people: %w", err)
+	}
+
+	names := map[string]string{}
+	for key, p := range resp.Results {
+		id := strings.TrimSuffix(strings.TrimPrefix(key, "(id:"), ")")
+		name := strings.TrimSpace(p.LocalizedFirstName + " " + p.LocalizedLastName)
+		if name != "" {
+			names["urn:li:person:"+id] = name
+		}
+	}
+	return names, nil
+}
+
+func (c *Client) lookupOrganizations(urns []string) (map[string]string, error) {
+	ids := make([]string, 0, len(urns))
+	for _, urn := range urns {
+		ids = append(ids, strings.TrimPrefix(urn, "urn:li:organization:"))
+	}
+	reqURL := fmt.Sprintf("%s/rest/organizationsLookup?ids=List(%s)", baseURL, strings.Join(ids, ","))
+
+	data, err := c.doRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up organizations: %w", err)
+	}
+
+	var resp organizationsLookupResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse organizations: %w", err)
+	}
+
+	names := map[string]string{}
+	for id, org := range resp.Results {
+		if org.LocalizedName != "" {
+			names["urn:li:organization:"+id] = org.LocalizedName
+		}
+	}
+	return names, nil
+}
+
+// nameOf returns the resolved name for urn, or the URN itself.
+func nameOf(names map[string]string, urn string) string {
+	if name, ok := names[urn]; ok {
+		return name
+	}
+	return urn
+}
@@ -0,0 +1,36 @@
+package linkedin
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/hev/socials/internal/auth"
+)
+
+const (
+	authorizationURL = "https://www.linkedin.com/oauth/v2/authorization"
+	tokenURL         = "https://www.linkedin.com/oauth/v2/accessToken"
+)
+
+// DefaultScopes covers reading the member's identity and posting.
+var DefaultScopes = []string{"openid", "profile", "email", "w_member_social"}
+
+// AuthorizationURL is where the member approves the app's access.
+func AuthorizationURL(clientID, redirectURI, state string, scopes []string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", clientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("state", state)
+	params.Set("scope", strings.Join(scopes, " "))
+	return authorizationURL + "?" + params.Encode()
+}
+
+// OAuthEndpoint returns LinkedIn's token endpoint for the app.
+func OAuthEndpoint(clientID, clientSecret string) auth.Endpoint {
+	return auth.Endpoint{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+}
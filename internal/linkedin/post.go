@@ -4,16 +4,30 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/hev/socials/internal/output"
 )
 
 type createPostRequest struct {
-	Author       string       `json:"author"`
-	Commentary   string       `json:"commentary"`
-	Visibility   string       `json:"visibility"`
-	Distribution distribution `json:"distribution"`
-	LifecycleState string    `json:"lifecycleState"`
+	Author                    string       `json:"author"`
+	Commentary                string       `json:"commentary"`
+	Visibility                string       `json:"visibility"`
+	Distribution              distribution `json:"distribution"`
+	LifecycleState            string       `json:"lifecycleState"`
+	IsReshareDisabledByAuthor bool         `json:"isReshareDisabledByAuthor"`
+	Content                   *postContent `json:"content,omitempty"`
+}
+
+type postContent struct {
+	Article *articleContent `json:"article,omitempty"`
+}
+
+type articleContent struct {
+	Source      string `json:"source"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Thumbnail   string `json:"thumbnail,omitempty"`
 }
 
 type distribution struct {
@@ -24,13 +38,103 @@ type createPostResponse struct {
 	ID string `json:"id"`
 }
 
-func (c *Client) CreatePost(text string) (*output.PostResult, error) {
+// PostOptions controls who sees a post, how it is distributed and what it
+// links to. The zero value is a plain public, published post in the main
+// feed.
+type PostOptions struct {
+	Visibility       string // PUBLIC, CONNECTIONS or LOGGED_IN
+	FeedDistribution string // MAIN_FEED, or NONE for a dark post
+	LifecycleState   string // PUBLISHED or DRAFT
+	DisableReshare   bool
+
+	Article *Article
+}
+
+// Article is a link shared as an article card. ThumbnailPath is a local
+// image that gets uploaded when the post is created.
+type Article struct {
+	Source        string
+	Title         string
+	Description   string
+	ThumbnailPath string
+}
+
+// Normalize fills in defaults, upper-cases values and checks them against
+// what the API accepts for the given kind of author.
+func (o *PostOptions) Normalize(asOrganization bool) error {
+	o.Visibility = strings.ToUpper(o.Visibility)
+	o.FeedDistribution = strings.ToUpper(o.FeedDistribution)
+	o.LifecycleState = strings.ToUpper(o.LifecycleState)
+
+	if o.Visibility == "" {
+		o.Visibility = "PUBLIC"
+	}
+	if o.FeedDistribution == "" {
+		o.FeedDistribution = "MAIN_FEED"
+	}
+	if o.LifecycleState == "" {
+		o.LifecycleState = "PUBLISHED"
+	}
+
+	switch o.Visibility {
+	case "PUBLIC", "LOGGED_IN":
+	case "CONNECTIONS":
+		if asOrganization {
+			return fmt.Errorf("CONNECTIONS visibility is not available when posting as an organization")
+		}
+	default:
+		return fmt.Errorf("unknown visibility %q (use public, connections or logged_in)", o.Visibility)
+	}
+
+	switch o.FeedDistribution {
+	case "MAIN_FEED", "NONE":
+	default:
+		return fmt.Errorf("unknown feed distribution %q (use main_feed or none)", o.FeedDistribution)
+	}
+
+	switch o.LifecycleState {
+	case "PUBLISHED", "DRAFT":
+	default:
+		return fmt.Errorf("unknown lifecycle state %q (use published or draft)", o.LifecycleState)
+	}
+
+	return nil
+}
+
+// IsOrganizationAuthor reports whether an author as accepted by ActAs
+// refers to an organization page.
+func IsOrganizationAuthor(as string) bool {
+	return as == "org" || as == "organization" || strings.HasPrefix(as, "urn:li:organization:")
+}
+
+func (c *Client) CreatePost(text string, opts PostOptions) (*output.PostResult, error) {
+	if err := opts.Normalize(IsOrganizationAuthor(c.author)); err != nil {
+		return nil, err
+	}
+
 	reqBody := createPostRequest{
-		Author:       c.personURN,
-		Commentary:   text,
-		Visibility:   "PUBLIC",
-		Distribution: distribution{FeedDistribution: "MAIN_FEED"},
-		LifecycleState: "PUBLISHED",
+		Author:                    c.author,
+		Commentary:                text,
+		Visibility:                opts.Visibility,
+		Distribution:              distribution{FeedDistribution: opts.FeedDistribution},
+		LifecycleState:            opts.LifecycleState,
+		IsReshareDisabledByAuthor: opts.DisableReshare,
+	}
+
+	if a := opts.Article; a != nil {
+		article := &articleContent{
+			Source:      a.Source,
+			Title:       a.Title,
+			Description: a.Description,
+		}
+		if a.ThumbnailPath != "" {
+			image, err := c.UploadImage(a.ThumbnailPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload article thumbnail: %w", err)
+			}
+			article.Thumbnail = image
+		}
+		reqBody.Content = &postContent{Article: article}
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -38,15 +142,21 @@ func (c *Client) CreatePost(text string) (*output.PostResult, error) {
 		return nil, fmt.Errorf("failed to marshal post: %w", err)
 	}
 
-	data, err := c.doRequest("POST", baseURL+"/rest/posts", bytes.NewReader(body))
+	data, id, err := c.create(baseURL+"/rest/posts", bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	// LinkedIn returns 201 with the ID in the x-restli-id header, and
+	// sometimes in the body as well.
 	var resp createPostResponse
-	// LinkedIn returns 201 with the ID in the response header or body
 	if len(data) > 0 {
-		json.Unmarshal(data, &resp)
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	if resp.ID == "" {
+		resp.ID = id
 	}
 
 	return &output.PostResult{
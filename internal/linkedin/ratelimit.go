@@ -0,0 +1,31 @@
+package linkedin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned when a request is rejected with 429. LinkedIn
+// only sometimes says when to retry, so Reset may be zero.
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "rate limited (429): too many requests, try again later"
+	}
+	return fmt.Sprintf("rate limited (429): too many requests, retry after %s", e.Reset.Local().Format("15:04:05"))
+}
+
+func retryAfter(h http.Header) time.Time {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(secs) * time.Second)
+	}
+	if t, err := http.ParseTime(h.Get("Retry-After")); err == nil {
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,76 @@
+package linkedin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hev/socials/internal/output"
+)
+
+type sendMessageRequest struct {
+	Recipients   []string `json:"recipients,omitempty"`
+	Conversation string   `json:"conversation,omitempty"`
+	Body         string   `json:"body"`
+	MessageType  string   `json:"messageType"`
+}
+
+type sendMessageResponse struct {
+	ID           string `json:"id"`
+	Conversation string `json:"conversation"`
+}
+
+// SendMessage sends text to a member (urn:li:person:...) or replies in an
+// existing conversation (any other ID, or any ID at all with conversation
+// set).
+func (c *Client) SendMessage(to, text string, conversation bool) (*output.SentMessage, error) {
+	reqBody := sendMessageRequest{
+		Body:        text,
+		MessageType: "MEMBER_TO_MEMBER",
+	}
+	var recipient string
+	if !conversation && strings.HasPrefix(to, "urn:li:person:") {
+		recipient = to
+		reqBody.Recipients = []string{to}
+	} else {
+		reqBody.Conversation = to
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	data, id, err := c.create(baseURL+"/rest/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	var resp sendMessageResponse
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	if resp.ID == "" {
+		resp.ID = id
+	}
+	if resp.ID == "" {
+		return nil, fmt.Errorf("message was sent but LinkedIn returned no ID")
+	}
+	conversationID := resp.Conversation
+	if conversationID == "" {
+		conversationID = reqBody.Conversation
+	}
+
+	return &output.SentMessage{
+		Network:        "linkedin",
+		ID:             resp.ID,
+		ConversationID: conversationID,
+		RecipientID:    recipient,
+		Text:           text,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,35 @@
+package linkedin
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UserInfo is the member behind the access token, from the OpenID Connect
+// userinfo endpoint.
+type UserInfo struct {
+	Sub   string `json:"sub"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// PersonURN returns the member's person URN, as used for posting.
+func (u *UserInfo) PersonURN() string {
+	return "urn:li:person:" + u.Sub
+}
+
+func (c *Client) GetUserInfo() (*UserInfo, error) {
+	data, err := c.doRequest("GET", baseURL+"/v2/userinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+
+	var info UserInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse user info: %w", err)
+	}
+	if info.Sub == "" {
+		return nil, fmt.Errorf("user info had no member ID")
+	}
+	return &info, nil
+}
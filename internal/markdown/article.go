@@ -0,0 +1,153 @@
+package markdown
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"golang.org/x/net/html"
+)
+
+// Article describes a link shared as a LinkedIn article card. Only URL is
+// required in front matter: Title and Description can be extracted from a
+// local copy of the page (HTML) and Title falls back to the post's first
+// heading. Thumbnail and HTML are paths relative to the markdown file.
+type Article struct {
+	URL         string `yaml:"url"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Thumbnail   string `yaml:"thumbnail"`
+	HTML        string `yaml:"html"`
+}
+
+// Article returns the post's article card with paths resolved against
+// baseDir and missing metadata filled in, or nil if the post has none.
+func (p *Post) Article(baseDir string) (*Article, error) {
+	if p.Meta.Article == nil {
+		return nil, nil
+	}
+	a := *p.Meta.Article
+
+	if a.URL == "" {
+		return nil, fmt.Errorf("article.url is required")
+	}
+	u, err := url.Parse(a.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("article.url %q must be an absolute http(s) URL", a.URL)
+	}
+
+	if a.HTML != "" {
+		a.HTML = resolvePath(baseDir, a.HTML)
+		title, description, err := htmlMetadata(a.HTML)
+		if err != nil {
+			return nil, err
+		}
+		if a.Title == "" {
+			a.Title = title
+		}
+		if a.Description == "" {
+			a.Description = description
+		}
+	}
+	if a.Title == "" {
+		a.Title = firstHeading(p.Body)
+	}
+	if a.Title == "" {
+		return nil, fmt.Errorf("article needs a title: set article.title, article.html or start the post with a heading")
+	}
+
+	if a.Thumbnail != "" {
+		a.Thumbnail = resolvePath(baseDir, a.Thumbnail)
+		if _, err := os.Stat(a.Thumbnail); err != nil {
+			return nil, fmt.Errorf("article thumbnail: %w", err)
+		}
+		switch strings.ToLower(filepath.Ext(a.Thumbnail)) {
+		case ".png", ".jpg", ".jpeg", ".gif":
+		default:
+			return nil, fmt.Errorf("article thumbnail %s must be a PNG, JPEG or GIF image", a.Thumbnail)
+		}
+	}
+
+	return &a, nil
+}
+
+func resolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) || baseDir == "" {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+func firstHeading(content string) string {
+	source := []byte(content)
+	doc := goldmark.New().Parser().Parse(text.NewReader(source))
+
+	for child := doc.FirstChild(); child != nil; child = child.NextSibling() {
+		if h, ok := child.(*ast.Heading); ok {
+			return extractText(h, source)
+		}
+	}
+	return ""
+}
+
+// htmlMetadata reads a page's title and description, preferring Open Graph
+// tags since that's what the card is meant to look like.
+func htmlMetadata(path string) (string, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read article html: %w", err)
+	}
+	defer f.Close()
+
+	doc, err := html.Parse(f)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse article html: %w", err)
+	}
+
+	meta := map[string]string{}
+	var title string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if title == "" && n.FirstChild != nil {
+					title = strings.TrimSpace(n.FirstChild.Data)
+				}
+			case "meta":
+				var key, content string
+				for _, attr := range n.Attr {
+					switch attr.Key {
+					case "name", "property":
+						key = strings.ToLower(attr.Val)
+					case "content":
+						content = strings.TrimSpace(attr.Val)
+					}
+				}
+				if key != "" && meta[key] == "" {
+					meta[key] = content
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if t := meta["og:title"]; t != "" {
+		title = t
+	}
+	description := meta["og:description"]
+	if description == "" {
+		description = meta["description"]
+	}
+
+	return title, description, nil
+}
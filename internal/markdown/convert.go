@@ -34,6 +34,19 @@ func ToTwitter(content string) []string {
 	return splitThread(text)
 }
 
+// ToPlainText renders markdown as plain text in a single piece, for places
+// like direct messages that have no length-based splitting.
+func ToPlainText(content string) string {
+	source := []byte(content)
+	md := goldmark.New()
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	var result strings.Builder
+	walkNode(doc, source, &result, false)
+
+	return strings.TrimSpace(result.String())
+}
+
 func ToLinkedIn(content string) string {
 	source := []byte(content)
 	md := goldmark.New()
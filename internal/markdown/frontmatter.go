@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelim = "---"
+
+// FrontMatter holds per-post options from an optional YAML block at the
+// top of a markdown file, delimited by "---" lines.
+type FrontMatter struct {
+	// PollDuration turns a trailing task list into a Twitter poll. It is
+	// either a number of minutes or a duration such as "24h".
+	PollDuration string `yaml:"poll_duration"`
+
+	// As selects the LinkedIn author: "person" (default) or "org".
+	As string `yaml:"as"`
+
+	// LinkedIn distribution options; see linkedin.PostOptions.
+	Visibility     string `yaml:"visibility"`
+	Dark           bool   `yaml:"dark"`
+	Draft          bool   `yaml:"draft"`
+	DisableReshare bool   `yaml:"disable_reshare"`
+
+	// Article shares a link on LinkedIn as an article card.
+	Article *Article `yaml:"article"`
+}
+
+// Post is a markdown file split into its front matter and body.
+type Post struct {
+	Meta FrontMatter
+	Body string
+}
+
+func ParsePost(content string) (*Post, error) {
+	post := &Post{Body: content}
+
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, frontMatterDelim+"\n") {
+		return post, nil
+	}
+
+	rest := normalized[len(frontMatterDelim)+1:]
+	var header string
+	if strings.HasPrefix(rest, frontMatterDelim+"\n") || rest == frontMatterDelim {
+		// Empty front matter block.
+		header, rest = "", strings.TrimPrefix(rest, frontMatterDelim)
+	} else {
+		end := strings.Index(rest, "\n"+frontMatterDelim+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+frontMatterDelim) {
+				return nil, fmt.Errorf("front matter is missing its closing %q", frontMatterDelim)
+			}
+			end = len(rest) - len(frontMatterDelim) - 1
+		}
+		header, rest = rest[:end], rest[end+len(frontMatterDelim)+1:]
+	}
+
+	if err := yaml.Unmarshal([]byte(header), &post.Meta); err != nil {
+		return nil, fmt.Errorf("failed to parse front matter: %w", err)
+	}
+	post.Body = strings.TrimLeft(rest, "\n")
+
+	return post, nil
+}
@@ -0,0 +1,117 @@
+package markdown
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	pollMinOptions      = 2
+	pollMaxOptions      = 4
+	pollMaxOptionChars  = 25
+	pollMinDurationMins = 5
+	pollMaxDurationMins = 7 * 24 * 60
+)
+
+// Poll is a Twitter poll declared by a post.
+type Poll struct {
+	Options         []string
+	DurationMinutes int
+}
+
+// TwitterPoll returns the poll declared by the post, or nil if it has none.
+// A post declares a poll by setting poll_duration in its front matter and
+// ending with a task list of options ("- [ ] Option"). The returned body
+// has the task list removed so it isn't repeated in the tweet text.
+func (p *Post) TwitterPoll() (string, *Poll, error) {
+	if p.Meta.PollDuration == "" {
+		return p.Body, nil, nil
+	}
+
+	minutes, err := parsePollDuration(p.Meta.PollDuration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	body, options := splitTaskList(p.Body)
+	if options == nil {
+		return "", nil, fmt.Errorf("poll_duration is set but the post does not end with a task list of poll options")
+	}
+
+	return body, &Poll{Options: options, DurationMinutes: minutes}, nil
+}
+
+func (p *Poll) Validate() error {
+	if len(p.Options) < pollMinOptions || len(p.Options) > pollMaxOptions {
+		return fmt.Errorf("poll must have %d-%d options, got %d", pollMinOptions, pollMaxOptions, len(p.Options))
+	}
+	for _, opt := range p.Options {
+		if opt == "" {
+			return fmt.Errorf("poll options must not be empty")
+		}
+		if n := utf8.RuneCountInString(opt); n > pollMaxOptionChars {
+			return fmt.Errorf("poll option %q is %d characters, max is %d", opt, n, pollMaxOptionChars)
+		}
+	}
+	if p.DurationMinutes < pollMinDurationMins || p.DurationMinutes > pollMaxDurationMins {
+		return fmt.Errorf("poll duration must be between %d minutes and 7 days, got %d minutes", pollMinDurationMins, p.DurationMinutes)
+	}
+	return nil
+}
+
+func parsePollDuration(s string) (int, error) {
+	if minutes, err := strconv.Atoi(s); err == nil {
+		return minutes, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid poll_duration %q: use minutes or a duration like 24h", s)
+	}
+	return int(d.Minutes()), nil
+}
+
+// splitTaskList removes a task list at the end of content and returns the
+// remaining content and the list items. It returns nil options if content
+// doesn't end with a task list.
+func splitTaskList(content string) (string, []string) {
+	source := []byte(content)
+	md := goldmark.New(goldmark.WithExtensions(extension.TaskList))
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	list, ok := doc.LastChild().(*ast.List)
+	if !ok {
+		return content, nil
+	}
+
+	var options []string
+	start := -1
+	for child := list.FirstChild(); child != nil; child = child.NextSibling() {
+		block := child.FirstChild()
+		if block == nil || block.Lines().Len() == 0 {
+			return content, nil
+		}
+		if _, ok := block.FirstChild().(*extast.TaskCheckBox); !ok {
+			return content, nil
+		}
+		if start < 0 {
+			start = block.Lines().At(0).Start
+		}
+		options = append(options, extractText(block, source))
+	}
+	if start < 0 {
+		return content, nil
+	}
+
+	// Cut at the beginning of the line holding the first item's marker.
+	cut := strings.LastIndex(content[:start], "\n") + 1
+	return strings.TrimSpace(content[:cut]), options
+}
@@ -21,20 +21,67 @@ func PrintJSON(data any) error {
 	return enc.Encode(data)
 }
 
+// PrintNDJSON writes data as a single line of JSON, for streaming output.
+func PrintNDJSON(data any) error {
+	return json.NewEncoder(os.Stdout).Encode(data)
+}
+
 func PrintHuman(data any) error {
 	switch v := data.(type) {
 	case []Tweet:
 		for _, t := range v {
 			fmt.Printf("@%s · %s\n", t.AuthorUsername, formatTime(t.CreatedAt))
+			if t.InReplyTo != "" {
+				fmt.Printf("↩ in reply to %s\n", t.InReplyTo)
+			}
 			fmt.Println(t.Text)
 			fmt.Printf("♥ %d  🔁 %d  💬 %d\n\n", t.Likes, t.Retweets, t.Replies)
 		}
+	case TweetPage:
+		PrintHuman(v.Tweets)
+		printNextCursor(v.NextCursor)
+	case LinkedInPostPage:
+		PrintHuman(v.Posts)
+		printNextCursor(v.NextCursor)
+	case TwitterUser:
+		fmt.Printf("%s (@%s)", v.Name, v.Username)
+		if v.Verified || (v.VerifiedType != "" && v.VerifiedType != "none") {
+			fmt.Print(" ✓")
+		}
+		fmt.Println()
+		if v.Description != "" {
+			fmt.Println(v.Description)
+		}
+		if v.Location != "" {
+			fmt.Printf("📍 %s\n", v.Location)
+		}
+		if v.URL != "" {
+			fmt.Printf("🔗 %s\n", v.URL)
+		}
+		fmt.Printf("%d followers · %d following · %d tweets\n", v.Followers, v.Following, v.Tweets)
+		fmt.Printf("ID: %s · joined %s\n", v.ID, formatTime(v.CreatedAt))
+	case []ThreadTweet:
+		for _, t := range v {
+			indent := strings.Repeat("  ", t.Depth)
+			fmt.Printf("%s@%s · %s · %s\n", indent, t.AuthorUsername, formatTime(t.CreatedAt), t.ID)
+			for _, line := range strings.Split(t.Text, "\n") {
+				fmt.Printf("%s%s\n", indent, line)
+			}
+			fmt.Printf("%s♥ %d  🔁 %d  💬 %d\n\n", indent, t.Likes, t.Retweets, t.Replies)
+		}
 	case []LinkedInPost:
 		for _, p := range v {
 			fmt.Printf("%s · %s\n", p.AuthorName, formatTime(p.CreatedAt))
 			fmt.Println(p.Text)
 			fmt.Printf("👍 %d  💬 %d\n\n", p.Likes, p.Comments)
 		}
+	case []LinkedInComment:
+		for _, c := range v {
+			printComment(c)
+			fmt.Println()
+		}
+	case LinkedInComment:
+		printComment(v)
 	case []DirectMessage:
 		for _, m := range v {
 			fmt.Printf("[%s] %s: %s\n", formatTime(m.CreatedAt), m.SenderName, m.Text)
@@ -43,6 +90,21 @@ func PrintHuman(data any) error {
 		for _, m := range v {
 			fmt.Printf("[%s] %s: %s\n", formatTime(m.CreatedAt), m.SenderName, m.Text)
 		}
+	case []Conversation:
+		for _, c := range v {
+			fmt.Printf("%s · %s · %d messages\n", participantNames(c.Participants), formatTime(c.LastMessageAt), len(c.Messages))
+			fmt.Printf("  %s\n", c.LastMessage)
+			fmt.Printf("  ID: %s\n\n", c.ID)
+		}
+	case Conversation:
+		fmt.Printf("%s · %s\n\n", participantNames(v.Participants), v.ID)
+		for _, m := range v.Messages {
+			name := m.SenderName
+			if name == "" {
+				name = m.SenderID
+			}
+			fmt.Printf("[%s] %s: %s\n", formatTime(m.CreatedAt), name, m.Text)
+		}
 	case PostResult:
 		fmt.Printf("Posted to %s\n", v.Network)
 		if v.ID != "" {
@@ -53,7 +115,11 @@ func PrintHuman(data any) error {
 		}
 	case []PostResult:
 		for _, r := range v {
-			fmt.Printf("Posted to %s\n", r.Network)
+			if r.Profile != "" {
+				fmt.Printf("Posted to %s as %s\n", r.Network, r.Profile)
+			} else {
+				fmt.Printf("Posted to %s\n", r.Network)
+			}
 			if r.ID != "" {
 				fmt.Printf("  ID: %s\n", r.ID)
 			}
@@ -61,6 +127,33 @@ func PrintHuman(data any) error {
 				fmt.Printf("  URL: %s\n", r.URL)
 			}
 		}
+	case SentMessage:
+		fmt.Printf("Sent on %s\n", v.Network)
+		if v.ConversationID != "" {
+			fmt.Printf("Conversation: %s\n", v.ConversationID)
+		}
+		if v.ID != "" {
+			fmt.Printf("ID: %s\n", v.ID)
+		}
+	case EngagementResult:
+		fmt.Printf("%s %s: %s\n", v.Network, v.Action, v.TweetID)
+		if v.URL != "" {
+			fmt.Printf("URL: %s\n", v.URL)
+		}
+	case DeleteResult:
+		fmt.Printf("Deleted from %s: %s\n", v.Network, v.ID)
+	case []DeleteResult:
+		for _, r := range v {
+			fmt.Printf("Deleted from %s: %s\n", r.Network, r.ID)
+		}
+	case EditResult:
+		if !v.Applied {
+			PrintDiff(v.Diff)
+			fmt.Println("(not applied)")
+			break
+		}
+		fmt.Printf("Updated %s post\n", v.Network)
+		fmt.Printf("ID: %s\n", v.ID)
 	case DryRunResult:
 		fmt.Printf("=== Dry Run: %s ===\n", v.Network)
 		for i, chunk := range v.Chunks {
@@ -69,27 +162,207 @@ func PrintHuman(data any) error {
 			}
 			fmt.Println(chunk)
 		}
+		if v.LinkedIn != nil {
+			o := v.LinkedIn
+			fmt.Printf("--- As %s · %s · %s · %s", o.Author, o.Visibility, o.FeedDistribution, o.LifecycleState)
+			if o.ReshareDisabled {
+				fmt.Print(" · reshares disabled")
+			}
+			fmt.Println(" ---")
+			if a := o.Article; a != nil {
+				fmt.Printf("--- Article: %s ---\n", a.URL)
+				fmt.Println(a.Title)
+				if a.Description != "" {
+					fmt.Println(a.Description)
+				}
+				if a.Thumbnail != "" {
+					fmt.Printf("Thumbnail: %s\n", a.Thumbnail)
+				}
+			}
+		}
+		if v.Poll != nil {
+			fmt.Printf("--- Poll (%d min) ---\n", v.Poll.DurationMinutes)
+			for _, opt := range v.Poll.Options {
+				fmt.Printf("( ) %s\n", opt)
+			}
+		}
 		fmt.Println()
 	case []DryRunResult:
 		for _, r := range v {
 			PrintHuman(r)
 		}
+	case []LintResult:
+		for _, r := range v {
+			if r.OK {
+				fmt.Printf("ok    %-8s %s\n", r.Network, r.File)
+			} else {
+				fmt.Printf("fail  %-8s %s: %s\n", r.Network, r.File, r.Error)
+			}
+		}
+	case LoginResult:
+		who := v.Account
+		if v.Name != "" {
+			who = fmt.Sprintf("%s (%s)", v.Name, v.Account)
+		}
+		fmt.Printf("Logged in to %s as %s\n", v.Network, who)
+		if v.Profile != "" {
+			fmt.Printf("  Profile: %s\n", v.Profile)
+		}
+		if v.Scopes != "" {
+			fmt.Printf("  Scopes:  %s\n", v.Scopes)
+		}
+		if v.ExpiresAt != "" {
+			fmt.Printf("  Expires: %s\n", formatTime(v.ExpiresAt))
+		}
 	case ConfigDisplay:
+		if len(v.Profiles) > 1 {
+			fmt.Printf("Profile: %s (of %s)\n", v.Profile, strings.Join(v.Profiles, ", "))
+		}
 		fmt.Println("Twitter:")
-		fmt.Printf("  API Key:       %s\n", v.Twitter.APIKey)
-		fmt.Printf("  API Secret:    %s\n", v.Twitter.APIKeySecret)
-		fmt.Printf("  Access Token:  %s\n", v.Twitter.AccessToken)
-		fmt.Printf("  Access Secret: %s\n", v.Twitter.AccessTokenSecret)
-		fmt.Printf("  User ID:       %s\n", v.Twitter.UserID)
+		if v.Twitter.AuthMode == "oauth2" {
+			fmt.Printf("  Auth:          OAuth 2.0\n")
+			fmt.Printf("  Access Token:  %s\n", v.Twitter.OAuth2Token)
+			if v.Twitter.ExpiresAt != "" {
+				fmt.Printf("  Expires:       %s\n", formatTime(v.Twitter.ExpiresAt))
+			}
+			fmt.Printf("  User ID:       %s\n", v.Twitter.UserID)
+		} else {
+			fmt.Printf("  API Key:       %s\n", v.Twitter.APIKey)
+			fmt.Printf("  API Secret:    %s\n", v.Twitter.APIKeySecret)
+			fmt.Printf("  Access Token:  %s\n", v.Twitter.AccessToken)
+			fmt.Printf("  Access Secret: %s\n", v.Twitter.AccessTokenSecret)
+			fmt.Printf("  User ID:       %s\n", v.Twitter.UserID)
+		}
 		fmt.Println("LinkedIn:")
 		fmt.Printf("  Access Token:  %s\n", v.LinkedIn.AccessToken)
 		fmt.Printf("  Person URN:    %s\n", v.LinkedIn.PersonURN)
+		if v.LinkedIn.OrganizationURN != "" {
+			fmt.Printf("  Org URN:       %s\n", v.LinkedIn.OrganizationURN)
+		}
+		if v.LinkedIn.ExpiresAt != "" {
+			fmt.Printf("  Expires:       %s\n", formatTime(v.LinkedIn.ExpiresAt))
+		}
+		if v.LinkedIn.RefreshTokenExpiresAt != "" {
+			fmt.Printf("  Refresh Until: %s\n", formatTime(v.LinkedIn.RefreshTokenExpiresAt))
+		}
+		for _, w := range v.Warnings {
+			fmt.Printf("\nWarning: %s\n", w)
+		}
+	case DoctorReport:
+		fmt.Printf("Profile: %s\n", v.Profile)
+		network := ""
+		for _, c := range v.Checks {
+			if c.Network != network {
+				network = c.Network
+				fmt.Printf("\n%s:\n", network)
+			}
+			fmt.Printf("  %-5s %-9s %s\n", c.Status, c.Check, c.Message)
+		}
+		if v.OK {
+			fmt.Println("\nAll checks passed.")
+		}
 	default:
 		return PrintJSON(data)
 	}
 	return nil
 }
 
+// PrintDiff prints diff lines from LineDiff to stdout.
+func PrintDiff(lines []string) {
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+}
+
+// LineDiff compares two texts line by line and returns every line prefixed
+// with "  " (unchanged), "- " (removed) or "+ " (added).
+func LineDiff(oldText, newText string) []string {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, "  "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, "+ "+b[j])
+	}
+	return diff
+}
+
+func printComment(c LinkedInComment) {
+	author := c.AuthorName
+	if author == "" {
+		author = c.AuthorURN
+	}
+	fmt.Printf("%s · %s\n", author, formatTime(c.CreatedAt))
+	fmt.Println(c.Text)
+	fmt.Printf("👍 %d  ID: %s\n", c.Likes, c.ID)
+}
+
+func participantNames(participants []Participant) string {
+	names := make([]string, 0, len(participants))
+	for _, p := range participants {
+		if p.Name != "" {
+			names = append(names, p.Name)
+		} else {
+			names = append(names, p.ID)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Preview shortens a message to one line for conversation listings.
+func Preview(text string) string {
+	const maxRunes = 80
+	line, _, _ := strings.Cut(text, "\n")
+	runes := []rune(line)
+	if len(runes) > maxRunes {
+		return string(runes[:maxRunes-1]) + "…"
+	}
+	if line != text {
+		return line + " …"
+	}
+	return line
+}
+
+func printNextCursor(cursor string) {
+	if cursor != "" {
+		fmt.Printf("Next cursor: %s\n", cursor)
+	}
+}
+
 func formatTime(t string) string {
 	parsed, err := time.Parse(time.RFC3339, t)
 	if err != nil {
@@ -111,60 +384,251 @@ func Redact(s string) string {
 // Output types
 
 type Tweet struct {
-	ID             string `json:"id"`
-	Text           string `json:"text"`
-	AuthorID       string `json:"author_id"`
-	AuthorUsername string `json:"author_username"`
-	CreatedAt      string `json:"created_at"`
-	Likes          int    `json:"likes"`
-	Retweets       int    `json:"retweets"`
-	Replies        int    `json:"replies"`
+	ID              string `json:"id"`
+	Text            string `json:"text"`
+	AuthorID        string `json:"author_id"`
+	AuthorUsername  string `json:"author_username"`
+	CreatedAt       string `json:"created_at"`
+	ConversationID  string `json:"conversation_id,omitempty"`
+	InReplyTo       string `json:"in_reply_to,omitempty"`
+	InReplyToUserID string `json:"in_reply_to_user_id,omitempty"`
+	Likes           int    `json:"likes"`
+	Retweets        int    `json:"retweets"`
+	Replies         int    `json:"replies"`
+}
+
+// ThreadTweet is a tweet placed in a conversation's reply tree. ParentID is
+// the tweet it is placed under, empty for the root. Orphans reply to a
+// tweet that wasn't found and are placed under the root instead.
+type ThreadTweet struct {
+	Tweet
+	ParentID string `json:"parent_id,omitempty"`
+	Depth    int    `json:"depth"`
+	Orphan   bool   `json:"orphan,omitempty"`
+}
+
+type TwitterUser struct {
+	ID              string `json:"id"`
+	Username        string `json:"username"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Location        string `json:"location,omitempty"`
+	URL             string `json:"url,omitempty"`
+	ProfileImageURL string `json:"profile_image_url,omitempty"`
+	CreatedAt       string `json:"created_at"`
+	Verified        bool   `json:"verified"`
+	VerifiedType    string `json:"verified_type,omitempty"`
+	Protected       bool   `json:"protected"`
+	Followers       int    `json:"followers"`
+	Following       int    `json:"following"`
+	Tweets          int    `json:"tweets"`
+	Listed          int    `json:"listed"`
+}
+
+// TweetPage is a page of tweets. NextCursor, when set, resumes after the
+// last tweet via --cursor.
+type TweetPage struct {
+	Tweets     []Tweet `json:"tweets"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type LinkedInPostPage struct {
+	Posts      []LinkedInPost `json:"posts"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 type LinkedInPost struct {
-	ID        string `json:"id"`
-	Text      string `json:"text"`
-	AuthorURN string `json:"author_urn"`
+	ID         string `json:"id"`
+	Text       string `json:"text"`
+	AuthorURN  string `json:"author_urn"`
 	AuthorName string `json:"author_name"`
-	CreatedAt string `json:"created_at"`
-	Likes     int    `json:"likes"`
-	Comments  int    `json:"comments"`
+	CreatedAt  string `json:"created_at"`
+	Likes      int    `json:"likes"`
+	Comments   int    `json:"comments"`
 }
 
-type DirectMessage struct {
+type LinkedInComment struct {
 	ID         string `json:"id"`
+	PostURN    string `json:"post_urn"`
 	Text       string `json:"text"`
-	SenderID   string `json:"sender_id"`
-	SenderName string `json:"sender_name"`
+	AuthorURN  string `json:"author_urn"`
+	AuthorName string `json:"author_name"`
 	CreatedAt  string `json:"created_at"`
+	Likes      int    `json:"likes"`
+}
+
+type DirectMessage struct {
+	ID             string `json:"id"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	Text           string `json:"text"`
+	SenderID       string `json:"sender_id"`
+	SenderName     string `json:"sender_name"`
+	CreatedAt      string `json:"created_at"`
 }
 
 type LinkedInMessage struct {
+	ID             string `json:"id"`
+	ConversationID string `json:"conversation_id"`
+	Text           string `json:"text"`
+	SenderURN      string `json:"sender_urn"`
+	SenderName     string `json:"sender_name"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// Conversation is a DM thread. Messages are oldest first; LastMessage is
+// a short preview of the newest one.
+type Conversation struct {
+	Network       string                `json:"network"`
+	ID            string                `json:"id"`
+	Participants  []Participant         `json:"participants"`
+	LastMessage   string                `json:"last_message"`
+	LastMessageAt string                `json:"last_message_at"`
+	Messages      []ConversationMessage `json:"messages"`
+}
+
+type Participant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ConversationMessage struct {
 	ID         string `json:"id"`
 	Text       string `json:"text"`
-	SenderURN  string `json:"sender_urn"`
+	SenderID   string `json:"sender_id"`
 	SenderName string `json:"sender_name"`
 	CreatedAt  string `json:"created_at"`
 }
 
+// SentMessage is a direct message we sent. RecipientID is set when the
+// message was addressed to a user rather than a conversation.
+type SentMessage struct {
+	Network        string `json:"network"`
+	ID             string `json:"id"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	RecipientID    string `json:"recipient_id,omitempty"`
+	Text           string `json:"text"`
+	CreatedAt      string `json:"created_at"`
+}
+
 type PostResult struct {
+	Profile string `json:"profile,omitempty"`
 	Network string `json:"network"`
 	ID      string `json:"id"`
 	URL     string `json:"url,omitempty"`
 	Text    string `json:"text"`
 }
 
-type DryRunResult struct {
+// EngagementResult is the outcome of a like/retweet style action. Active
+// reports the resulting state, e.g. false after a successful unlike.
+type EngagementResult struct {
+	Network string `json:"network"`
+	Action  string `json:"action"`
+	TweetID string `json:"tweet_id"`
+	URL     string `json:"url,omitempty"`
+	Active  bool   `json:"active"`
+}
+
+// WatchEvent is one new item seen by 'socials watch'. Item is the same
+// shape the matching list command prints, e.g. a Tweet for feed twitter.
+type WatchEvent struct {
+	Network string `json:"network"`
+	Kind    string `json:"kind"`
+	Item    any    `json:"item"`
+	SeenAt  string `json:"seen_at"`
+}
+
+type DeleteResult struct {
+	Network string `json:"network"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+type EditResult struct {
 	Network string   `json:"network"`
-	Chunks  []string `json:"chunks"`
+	ID      string   `json:"id"`
+	OldText string   `json:"old_text"`
+	NewText string   `json:"new_text"`
+	Diff    []string `json:"diff"`
+	Applied bool     `json:"applied"`
+}
+
+type DryRunResult struct {
+	Network string       `json:"network"`
+	Chunks  []string     `json:"chunks"`
+	Poll    *PollPreview `json:"poll,omitempty"`
+
+	LinkedIn *LinkedInPostOptions `json:"linkedin,omitempty"`
+}
+
+type LinkedInPostOptions struct {
+	Author           string `json:"author"`
+	Visibility       string `json:"visibility"`
+	FeedDistribution string `json:"feed_distribution"`
+	LifecycleState   string `json:"lifecycle_state"`
+	ReshareDisabled  bool   `json:"reshare_disabled"`
+
+	Article *ArticlePreview `json:"article,omitempty"`
+}
+
+type ArticlePreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Thumbnail   string `json:"thumbnail,omitempty"`
+}
+
+// LintResult is whether a post file would be accepted by a network.
+type LintResult struct {
+	File    string `json:"file"`
+	Network string `json:"network"`
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+}
+
+type PollPreview struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// LoginResult is the account a login stored tokens for.
+type LoginResult struct {
+	Network   string `json:"network"`
+	Profile   string `json:"profile"`
+	Account   string `json:"account"`
+	Name      string `json:"name,omitempty"`
+	Scopes    string `json:"scopes,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// DoctorReport is the outcome of verifying a profile's credentials
+// against the APIs. OK is false if any check failed.
+type DoctorReport struct {
+	Profile string        `json:"profile"`
+	OK      bool          `json:"ok"`
+	Checks  []DoctorCheck `json:"checks"`
+}
+
+// DoctorCheck is one verified aspect of a network's credentials. Status is
+// "ok", "warn", "fail" or "skip".
+type DoctorCheck struct {
+	Network string `json:"network"`
+	Check   string `json:"check"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
 }
 
 type ConfigDisplay struct {
+	Profile  string                `json:"profile"`
+	Profiles []string              `json:"profiles"`
 	Twitter  ConfigTwitterDisplay  `json:"twitter"`
 	LinkedIn ConfigLinkedInDisplay `json:"linkedin"`
+	Warnings []string              `json:"warnings,omitempty"`
 }
 
 type ConfigTwitterDisplay struct {
+	AuthMode          string `json:"auth_mode,omitempty"`
+	OAuth2Token       string `json:"oauth2_access_token,omitempty"`
+	ExpiresAt         string `json:"expires_at,omitempty"`
 	APIKey            string `json:"api_key"`
 	APIKeySecret      string `json:"api_key_secret"`
 	AccessToken       string `json:"access_token"`
@@ -173,6 +637,10 @@ type ConfigTwitterDisplay struct {
 }
 
 type ConfigLinkedInDisplay struct {
-	AccessToken string `json:"access_token"`
-	PersonURN   string `json:"person_urn"`
+	AccessToken     string `json:"access_token"`
+	PersonURN       string `json:"person_urn"`
+	OrganizationURN string `json:"organization_urn,omitempty"`
+
+	ExpiresAt             string `json:"expires_at,omitempty"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at,omitempty"`
 }
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"slices"
+	"time"
+)
+
+const checkpointsFile = "checkpoints.json"
+
+// Checkpoint marks the newest item seen in a feed. Twitter feeds track the
+// highest tweet ID, LinkedIn feeds the newest creation time along with the
+// IDs of the posts created at that time, since others can share it.
+type Checkpoint struct {
+	SinceID   string   `json:"since_id,omitempty"`
+	Since     string   `json:"since,omitempty"`
+	SeenIDs   []string `json:"seen_ids,omitempty"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// CheckpointKey identifies a feed's checkpoint by profile and network.
+func CheckpointKey(profile, network string) string {
+	return profile + "/" + network
+}
+
+func LoadCheckpoint(key string) (Checkpoint, error) {
+	checkpoints := map[string]Checkpoint{}
+	if err := ReadJSON(checkpointsFile, &checkpoints); err != nil {
+		return Checkpoint{}, err
+	}
+	return checkpoints[key], nil
+}
+
+func SaveCheckpoint(key string, cp Checkpoint) error {
+	checkpoints := map[string]Checkpoint{}
+	if err := ReadJSON(checkpointsFile, &checkpoints); err != nil {
+		return err
+	}
+
+	cp.UpdatedAt = time.Now().Format(time.RFC3339)
+	checkpoints[key] = cp
+	return WriteJSON(checkpointsFile, checkpoints)
+}
+
+// SinceTime returns the checkpoint's timestamp, or the zero time if unset.
+func (cp Checkpoint) SinceTime() time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, cp.Since)
+	return t
+}
+
+// SeeID advances SinceID if id is newer. Tweet IDs are snowflakes, which
+// order by length first and then lexically.
+func (cp *Checkpoint) SeeID(id string) {
+	if len(id) > len(cp.SinceID) || (len(id) == len(cp.SinceID) && id > cp.SinceID) {
+		cp.SinceID = id
+	}
+}
+
+// SeeTime records the item id created at t, advancing Since if t is newer.
+func (cp *Checkpoint) SeeTime(t time.Time, id string) {
+	since := cp.SinceTime()
+	switch {
+	case t.After(since):
+		cp.Since = t.Format(time.RFC3339Nano)
+		cp.SeenIDs = []string{id}
+	case t.Equal(since) && !slices.Contains(cp.SeenIDs, id):
+		cp.SeenIDs = append(cp.SeenIDs, id)
+	}
+}
+
+// Seen reports whether the item id created at t is at or before the
+// checkpoint.
+func (cp Checkpoint) Seen(t time.Time, id string) bool {
+	since := cp.SinceTime()
+	return t.Before(since) || (t.Equal(since) && slices.Contains(cp.SeenIDs, id))
+}
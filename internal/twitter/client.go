@@ -1,12 +1,15 @@
 package twitter
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/dghubble/oauth1"
+	"github.com/hev/socials/internal/auth"
 	"github.com/hev/socials/internal/config"
 )
 
@@ -15,9 +18,45 @@ const baseURL = "https://api.twitter.com/2"
 type Client struct {
 	httpClient *http.Client
 	userID     string
+	rateLimit  RateLimit
+
+	// bearerToken is the OAuth 2.0 user token. Without one, httpClient
+	// signs requests with OAuth 1.0a.
+	bearerToken string
+
+	// refresher renews bearerToken; nil without a refresh token.
+	refresher *auth.Refresher
+
+	// clockSkew and accessLevel come from the most recent response that
+	// carried them.
+	clockSkew   time.Duration
+	clockKnown  bool
+	accessLevel string
 }
 
+// NewClient authenticates with OAuth 1.0a, or with an OAuth 2.0 user token
+// when the config's auth_mode says so.
 func NewClient(cfg *config.TwitterConfig) *Client {
+	if cfg.UsesOAuth2() {
+		c := &Client{
+			httpClient:  &http.Client{},
+			userID:      cfg.UserID,
+			bearerToken: cfg.OAuth2AccessToken,
+		}
+		if cfg.RefreshToken != "" && cfg.ClientID != "" {
+			c.refresher = &auth.Refresher{
+				Endpoint:     OAuthEndpoint(cfg.ClientID, cfg.ClientSecret),
+				RefreshToken: cfg.RefreshToken,
+				ExpiresAt:    auth.ParseTime(cfg.ExpiresAt),
+				Save:         cfg.SaveToken,
+			}
+			if cfg.FixedRefreshToken {
+				c.refresher.Disabled = "token not refreshed: refresh_token is set by reference and would be used up, run 'socials auth login twitter --oauth2' and update the reference"
+			}
+		}
+		return c
+	}
+
 	oauthConfig := oauth1.NewConfig(cfg.APIKey, cfg.APIKeySecret)
 	token := oauth1.NewToken(cfg.AccessToken, cfg.AccessTokenSecret)
 	return &Client{
@@ -26,27 +65,40 @@ func NewClient(cfg *config.TwitterConfig) *Client {
 	}
 }
 
+// doRequest sends an API request. With OAuth 2.0, an expired token is
+// refreshed first, and a 401 triggers one refresh and retry, so the body
+// is buffered.
 func (c *Client) doRequest(method, url string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	refreshed := false
+	if c.refresher != nil && c.refresher.Expired() {
+		// On failure, try the old token anyway; a 401 explains the rest.
+		refreshed = c.refresh() == nil
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, data, err := c.send(method, url, payload)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	if resp.StatusCode == 401 && c.refresher != nil && !refreshed {
+		if err := c.refresh(); err != nil {
+			return nil, fmt.Errorf("authentication failed (401) and %w", err)
+		}
+		if resp, data, err = c.send(method, url, payload); err != nil {
+			return nil, err
+		}
 	}
 
+	c.rateLimit = parseRateLimit(resp.Header)
+
 	if resp.StatusCode == 401 {
 		return nil, fmt.Errorf("authentication failed (401): check your Twitter API tokens")
 	}
@@ -54,7 +106,7 @@ func (c *Client) doRequest(method, url string, body io.Reader) ([]byte, error) {
 		return nil, fmt.Errorf("forbidden (403): check your Twitter API access level")
 	}
 	if resp.StatusCode == 429 {
-		return nil, fmt.Errorf("rate limited (429): too many requests, try again later")
+		return nil, &RateLimitError{Reset: c.rateLimit.Reset}
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		var apiErr struct {
@@ -69,3 +121,73 @@ func (c *Client) doRequest(method, url string, body io.Reader) ([]byte, error) {
 
 	return data, nil
 }
+
+func (c *Client) send(method, url string, payload []byte) (*http.Response, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if skew, ok := auth.ClockSkew(resp.Header); ok {
+		c.clockSkew, c.clockKnown = skew, true
+	}
+	if level := resp.Header.Get("x-access-level"); level != "" {
+		c.accessLevel = level
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp, data, nil
+}
+
+// ClockSkew returns how far Twitter's clock is ahead of the local one, as
+// of the last response. OAuth 1.0a signatures are rejected when the skew
+// is large. ok is false before any response.
+func (c *Client) ClockSkew() (skew time.Duration, ok bool) {
+	return c.clockSkew, c.clockKnown
+}
+
+// AccessLevel returns the app permissions granted to OAuth 1.0a tokens,
+// e.g. "read-write-directmessages", from the last response. It is empty
+// for OAuth 2.0 tokens.
+func (c *Client) AccessLevel() string {
+	return c.accessLevel
+}
+
+// TokenExpiry returns when the access token expires, taking refreshes made
+// by the client into account. It is zero when unknown or without a refresh
+// token.
+func (c *Client) TokenExpiry() time.Time {
+	if c.refresher == nil {
+		return time.Time{}
+	}
+	return c.refresher.Expiry()
+}
+
+func (c *Client) refresh() error {
+	token, err := c.refresher.Refresh()
+	if err != nil {
+		return err
+	}
+	c.bearerToken = token
+	return nil
+}
@@ -0,0 +1,91 @@
+package twitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hev/socials/internal/output"
+)
+
+type deleteTweetResponse struct {
+	Data struct {
+		Deleted bool `json:"deleted"`
+	} `json:"data"`
+}
+
+type tweetLookupResponse struct {
+	Data struct {
+		ID             string `json:"id"`
+		AuthorID       string `json:"author_id"`
+		ConversationID string `json:"conversation_id"`
+	} `json:"data"`
+}
+
+func (c *Client) DeleteTweet(id string) (*output.DeleteResult, error) {
+	data, err := c.doRequest("DELETE", fmt.Sprintf("%s/tweets/%s", baseURL, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete tweet %s: %w", id, err)
+	}
+
+	var resp deleteTweetResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &output.DeleteResult{
+		Network: "twitter",
+		ID:      id,
+		Deleted: resp.Data.Deleted,
+	}, nil
+}
+
+// ConversationThread returns the IDs of our own tweets in the conversation
+// that contains id, oldest first, id itself included. Recent search only covers the last seven
+// days, so older threads have to come from local history.
+func (c *Client) ConversationThread(id string) ([]string, error) {
+	data, err := c.doRequest("GET", fmt.Sprintf("%s/tweets/%s?tweet.fields=conversation_id,author_id", baseURL, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up tweet %s: %w", id, err)
+	}
+
+	var tweet tweetLookupResponse
+	if err := json.Unmarshal(data, &tweet); err != nil {
+		return nil, fmt.Errorf("failed to parse tweet: %w", err)
+	}
+	if tweet.Data.AuthorID != c.userID {
+		return nil, fmt.Errorf("tweet %s was not posted by this account", id)
+	}
+
+	rootID := tweet.Data.ConversationID
+	if rootID == "" {
+		rootID = id
+	}
+
+	query := fmt.Sprintf("conversation_id:%s from:%s", rootID, c.userID)
+	page, err := c.SearchRecent(query, "", PageOptions{Count: maxPageSize, All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search conversation: %w", err)
+	}
+
+	// The root only counts if the search finds it, i.e. if it is ours;
+	// replies to someone else's tweet must leave their root alone.
+	ids := []string{id}
+	for _, t := range page.Tweets {
+		if t.ID != id {
+			ids = append(ids, t.ID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return lessID(ids[i], ids[j]) })
+
+	return ids, nil
+}
+
+// lessID compares numeric tweet IDs without parsing them, since snowflake
+// IDs sort by length first and then lexically.
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -0,0 +1,66 @@
+package twitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hev/socials/internal/output"
+)
+
+type sendDMRequest struct {
+	Text string `json:"text"`
+}
+
+type sendDMResponse struct {
+	Data struct {
+		DMConversationID string `json:"dm_conversation_id"`
+		DMEventID        string `json:"dm_event_id"`
+	} `json:"data"`
+}
+
+// SendDirectMessage sends text to a user, given as anything ResolveUserID
+// takes.
+func (c *Client) SendDirectMessage(to, text string) (*output.SentMessage, error) {
+	userID, err := c.ResolveUserID(to)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/dm_conversations/with/%s/messages", baseURL, url.PathEscape(userID))
+	return c.sendDM(endpoint, userID, text)
+}
+
+// SendToConversation sends text to an existing one-to-one or group
+// conversation.
+func (c *Client) SendToConversation(conversationID, text string) (*output.SentMessage, error) {
+	endpoint := fmt.Sprintf("%s/dm_conversations/%s/messages", baseURL, url.PathEscape(conversationID))
+	return c.sendDM(endpoint, "", text)
+}
+
+func (c *Client) sendDM(endpoint, recipient, text string) (*output.SentMessage, error) {
+	body, err := json.Marshal(sendDMRequest{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	data, err := c.doRequest("POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send DM: %w", err)
+	}
+
+	var resp sendDMResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &output.SentMessage{
+		Network:        "twitter",
+		ID:             resp.Data.DMEventID,
+		ConversationID: resp.Data.DMConversationID,
+		RecipientID:    recipient,
+		Text:           text,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package twitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+)
+
+type tweetIDRequest struct {
+	TweetID string `json:"tweet_id"`
+}
+
+type engagementResponse struct {
+	Data struct {
+		Retweeted *bool `json:"retweeted"`
+		Liked     *bool `json:"liked"`
+	} `json:"data"`
+}
+
+func (c *Client) Retweet(tweetID string) (*output.EngagementResult, error) {
+	return c.engage("retweet", "POST", fmt.Sprintf("%s/users/%s/retweets", baseURL, c.userID), tweetID)
+}
+
+func (c *Client) Unretweet(tweetID string) (*output.EngagementResult, error) {
+	return c.engage("unretweet", "DELETE", fmt.Sprintf("%s/users/%s/retweets/%s", baseURL, c.userID, tweetID), tweetID)
+}
+
+func (c *Client) Like(tweetID string) (*output.EngagementResult, error) {
+	return c.engage("like", "POST", fmt.Sprintf("%s/users/%s/likes", baseURL, c.userID), tweetID)
+}
+
+func (c *Client) Unlike(tweetID string) (*output.EngagementResult, error) {
+	return c.engage("unlike", "DELETE", fmt.Sprintf("%s/users/%s/likes/%s", baseURL, c.userID, tweetID), tweetID)
+}
+
+// engage performs a retweet/like style call. POST requests carry the tweet
+// ID in the body, DELETE requests in the URL.
+func (c *Client) engage(action, method, url, tweetID string) (*output.EngagementResult, error) {
+	var data []byte
+	var err error
+
+	if method == "POST" {
+		body, merr := json.Marshal(tweetIDRequest{TweetID: tweetID})
+		if merr != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", merr)
+		}
+		data, err = c.doRequest(method, url, bytes.NewReader(body))
+	} else {
+		data, err = c.doRequest(method, url, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s tweet %s: %w", action, tweetID, err)
+	}
+
+	var resp engagementResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	state := resp.Data.Retweeted
+	if state == nil {
+		state = resp.Data.Liked
+	}
+	active := state != nil && *state
+
+	return &output.EngagementResult{
+		Network: "twitter",
+		Action:  action,
+		TweetID: tweetID,
+		URL:     fmt.Sprintf("https://twitter.com/i/status/%s", tweetID),
+		Active:  active,
+	}, nil
+}
@@ -0,0 +1,18 @@
+package twitter
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+)
+
+// GetMentions returns tweets mentioning the configured user, newest first.
+func (c *Client) GetMentions(opts PageOptions) (*output.TweetPage, error) {
+	endpoint := fmt.Sprintf("%s/users/%s/mentions", baseURL, c.userID)
+
+	page, err := c.listTweets(endpoint, tweetListParams(), opts, "pagination_token", 5)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentions: %w", err)
+	}
+	return page, nil
+}
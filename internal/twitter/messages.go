@@ -3,6 +3,9 @@ package twitter
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hev/socials/internal/output"
@@ -10,11 +13,13 @@ import (
 
 type dmEventsResponse struct {
 	Data []struct {
-		ID        string `json:"id"`
-		Text      string `json:"text"`
-		EventType string `json:"event_type"`
-		SenderID  string `json:"sender_id"`
-		CreatedAt string `json:"created_at"`
+		ID               string   `json:"id"`
+		Text             string   `json:"text"`
+		EventType        string   `json:"event_type"`
+		SenderID         string   `json:"sender_id"`
+		DMConversationID string   `json:"dm_conversation_id"`
+		ParticipantIDs   []string `json:"participant_ids"`
+		CreatedAt        string   `json:"created_at"`
 	} `json:"data"`
 	Includes struct {
 		Users []struct {
@@ -25,43 +30,189 @@ type dmEventsResponse struct {
 	} `json:"includes"`
 }
 
+// maxDMEvents is the most DM events one request returns.
+const maxDMEvents = 100
+
+const dmEventParams = "dm_event.fields=created_at,sender_id,dm_conversation_id,participant_ids&event_types=MessageCreate&expansions=sender_id,participant_ids&user.fields=username,name"
+
 func (c *Client) GetDirectMessages(count int) ([]output.DirectMessage, error) {
 	if count <= 0 {
 		count = 10
 	}
+	count = min(count, maxDMEvents)
+
+	endpoint := fmt.Sprintf("%s/dm_events?max_results=%d&%s", baseURL, count, dmEventParams)
+
+	resp, err := c.getDMEvents(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DMs: %w", err)
+	}
+
+	messages, _ := resp.messages()
+	return messages, nil
+}
+
+// GetDMConversations returns the count most recently active conversations
+// among the latest DM events, most recent first.
+func (c *Client) GetDMConversations(count int) ([]output.Conversation, error) {
+	if count <= 0 {
+		count = 10
+	}
 
-	url := fmt.Sprintf("%s/dm_events?max_results=%d&dm_event.fields=created_at,sender_id&event_types=MessageCreate&expansions=sender_id&user.fields=username,name",
-		baseURL, count)
+	endpoint := fmt.Sprintf("%s/dm_events?max_results=%d&%s", baseURL, maxDMEvents, dmEventParams)
 
-	data, err := c.doRequest("GET", url, nil)
+	resp, err := c.getDMEvents(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DMs: %w", err)
 	}
 
+	messages, participants := resp.messages()
+	conversations := groupConversations("twitter", messages)
+	if len(conversations) > count {
+		conversations = conversations[:count]
+	}
+	for i := range conversations {
+		addParticipants(&conversations[i], participants[conversations[i].ID])
+	}
+	return conversations, nil
+}
+
+// GetDMConversation returns up to count messages of one conversation.
+func (c *Client) GetDMConversation(conversationID string, count int) (*output.Conversation, error) {
+	if count <= 0 {
+		count = 50
+	}
+	count = min(count, maxDMEvents)
+
+	endpoint := fmt.Sprintf("%s/dm_conversations/%s/dm_events?max_results=%d&%s", baseURL, url.PathEscape(conversationID), count, dmEventParams)
+
+	resp, err := c.getDMEvents(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation %s: %w", conversationID, err)
+	}
+
+	messages, participants := resp.messages()
+	for i := range messages {
+		messages[i].ConversationID = conversationID
+	}
+
+	conversations := groupConversations("twitter", messages)
+	if len(conversations) == 0 {
+		return &output.Conversation{Network: "twitter", ID: conversationID, Messages: []output.ConversationMessage{}}, nil
+	}
+	conv := &conversations[0]
+	// Events of a single conversation don't always name it.
+	for _, ps := range participants {
+		addParticipants(conv, ps)
+	}
+	return conv, nil
+}
+
+func (c *Client) getDMEvents(endpoint string) (*dmEventsResponse, error) {
+	data, err := c.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp dmEventsResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse DMs: %w", err)
 	}
+	return &resp, nil
+}
 
+// messages converts the events to messages, newest first as returned by
+// the API, along with the participants of each conversation. Those come
+// from the senders, from participant_ids (group conversations) and from
+// the IDs of one-to-one conversations, which are the two user IDs joined
+// by a dash.
+func (resp *dmEventsResponse) messages() ([]output.DirectMessage, map[string][]output.Participant) {
 	userMap := make(map[string]string)
 	for _, u := range resp.Includes.Users {
 		userMap[u.ID] = u.Username
 	}
 
+	participants := map[string][]output.Participant{}
+	addParticipant := func(conversationID, id string) {
+		if id == "" || strings.Trim(id, "0123456789") != "" {
+			return
+		}
+		if !slices.ContainsFunc(participants[conversationID], func(p output.Participant) bool { return p.ID == id }) {
+			participants[conversationID] = append(participants[conversationID], output.Participant{ID: id, Name: userMap[id]})
+		}
+	}
+
 	messages := make([]output.DirectMessage, 0, len(resp.Data))
 	for _, m := range resp.Data {
 		createdAt := m.CreatedAt
 		if createdAt == "" {
 			createdAt = time.Now().Format(time.RFC3339)
 		}
+		addParticipant(m.DMConversationID, m.SenderID)
+		for _, id := range m.ParticipantIDs {
+			addParticipant(m.DMConversationID, id)
+		}
+		if a, b, ok := strings.Cut(m.DMConversationID, "-"); ok {
+			addParticipant(m.DMConversationID, a)
+			addParticipant(m.DMConversationID, b)
+		}
 		messages = append(messages, output.DirectMessage{
+			ID:             m.ID,
+			ConversationID: m.DMConversationID,
+			Text:           m.Text,
+			SenderID:       m.SenderID,
+			SenderName:     userMap[m.SenderID],
+			CreatedAt:      createdAt,
+		})
+	}
+
+	return messages, participants
+}
+
+// addParticipants adds the participants conv doesn't list yet.
+func addParticipants(conv *output.Conversation, participants []output.Participant) {
+	for _, p := range participants {
+		if !slices.ContainsFunc(conv.Participants, func(q output.Participant) bool { return q.ID == p.ID }) {
+			conv.Participants = append(conv.Participants, p)
+		}
+	}
+}
+
+// groupConversations groups newest-first messages by conversation. The
+// conversations keep that order, and each one's messages are returned
+// oldest first so they read like a chat.
+func groupConversations(network string, messages []output.DirectMessage) []output.Conversation {
+	var conversations []output.Conversation
+	index := map[string]int{}
+
+	for _, m := range messages {
+		i, ok := index[m.ConversationID]
+		if !ok {
+			i = len(conversations)
+			index[m.ConversationID] = i
+			conversations = append(conversations, output.Conversation{
+				Network:       network,
+				ID:            m.ConversationID,
+				LastMessage:   output.Preview(m.Text),
+				LastMessageAt: m.CreatedAt,
+			})
+		}
+		conv := &conversations[i]
+
+		if !slices.ContainsFunc(conv.Participants, func(p output.Participant) bool { return p.ID == m.SenderID }) {
+			conv.Participants = append(conv.Participants, output.Participant{ID: m.SenderID, Name: m.SenderName})
+		}
+		conv.Messages = append(conv.Messages, output.ConversationMessage{
 			ID:         m.ID,
 			Text:       m.Text,
 			SenderID:   m.SenderID,
-			SenderName: userMap[m.SenderID],
-			CreatedAt:  createdAt,
+			SenderName: m.SenderName,
+			CreatedAt:  m.CreatedAt,
 		})
 	}
 
-	return messages, nil
+	for i := range conversations {
+		slices.Reverse(conversations[i].Messages)
+	}
+	return conversations
 }
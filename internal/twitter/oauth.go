@@ -0,0 +1,45 @@
+package twitter
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/hev/socials/internal/auth"
+)
+
+const (
+	authorizationURL = "https://twitter.com/i/oauth2/authorize"
+	tokenURL         = "https://api.twitter.com/2/oauth2/token"
+)
+
+// DefaultScopes covers what the socials commands do. offline.access
+// yields a refresh token.
+var DefaultScopes = []string{
+	"tweet.read", "tweet.write", "users.read", "like.write",
+	"dm.read", "dm.write", "offline.access",
+}
+
+// AuthorizationURL is where the user approves the app's access, using
+// PKCE.
+func AuthorizationURL(clientID, redirectURI, state string, pkce auth.PKCE, scopes []string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", clientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("state", state)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("code_challenge", pkce.Challenge)
+	params.Set("code_challenge_method", "S256")
+	return authorizationURL + "?" + params.Encode()
+}
+
+// OAuthEndpoint returns Twitter's token endpoint for the app. The secret
+// is empty for public clients.
+func OAuthEndpoint(clientID, clientSecret string) auth.Endpoint {
+	return auth.Endpoint{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		BasicAuth:    true,
+	}
+}
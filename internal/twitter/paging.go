@@ -0,0 +1,215 @@
+package twitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hev/socials/internal/output"
+)
+
+const maxPageSize = 100
+
+// Cursors handed out when a page is cut short by Limit, since pagination
+// tokens can only point past a whole page. Newest-first lists resume below
+// the last tweet returned ("until:<id>"); others, like search by
+// relevancy, refetch the cut page and skip what was already returned
+// ("skip:<n>:<token>").
+const (
+	untilCursor = "until:"
+	skipCursor  = "skip:"
+)
+
+// PageOptions selects which tweets to fetch from a paginated endpoint.
+// Without Limit or All a single page of Count tweets is returned.
+type PageOptions struct {
+	Count  int    // tweets per request
+	Limit  int    // stop once this many tweets have been fetched
+	All    bool   // keep fetching until the last page
+	Cursor string // next_cursor from a previous page
+
+	SinceID   string
+	UntilID   string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type tweetData struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"`
+	AuthorID         string `json:"author_id"`
+	CreatedAt        string `json:"created_at"`
+	ConversationID   string `json:"conversation_id"`
+	InReplyToUserID  string `json:"in_reply_to_user_id"`
+	ReferencedTweets []struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	} `json:"referenced_tweets"`
+	PublicMetrics struct {
+		LikeCount    int `json:"like_count"`
+		RetweetCount int `json:"retweet_count"`
+		ReplyCount   int `json:"reply_count"`
+	} `json:"public_metrics"`
+}
+
+// inReplyTo returns the ID of the tweet this one replies to, if any.
+func (t *tweetData) inReplyTo() string {
+	for _, ref := range t.ReferencedTweets {
+		if ref.Type == "replied_to" {
+			return ref.ID
+		}
+	}
+	return ""
+}
+
+type tweetsResponse struct {
+	Data     []tweetData `json:"data"`
+	Includes struct {
+		Users []struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		} `json:"users"`
+	} `json:"includes"`
+	Meta struct {
+		NextToken string `json:"next_token"`
+	} `json:"meta"`
+}
+
+// tweetListParams holds the fields and expansions every tweet list asks for,
+// so all of them render the same output.Tweet.
+func tweetListParams() url.Values {
+	params := url.Values{}
+	params.Set("tweet.fields", "created_at,public_metrics,author_id,conversation_id,in_reply_to_user_id,referenced_tweets")
+	params.Set("expansions", "author_id")
+	params.Set("user.fields", "username")
+	return params
+}
+
+// listTweets pages through a tweet list endpoint. tokenParam is the query
+// parameter the endpoint takes the next_token in (search differs from the
+// timelines) and minResults its lower bound for max_results.
+func (c *Client) listTweets(endpoint string, params url.Values, opts PageOptions, tokenParam string, minResults int) (*output.TweetPage, error) {
+	count := opts.Count
+	if count <= 0 {
+		count = 10
+	}
+
+	if opts.SinceID != "" {
+		params.Set("since_id", opts.SinceID)
+	}
+	if opts.UntilID != "" {
+		params.Set("until_id", opts.UntilID)
+	}
+	if !opts.StartTime.IsZero() {
+		params.Set("start_time", opts.StartTime.UTC().Format(time.RFC3339))
+	}
+	if !opts.EndTime.IsZero() {
+		params.Set("end_time", opts.EndTime.UTC().Format(time.RFC3339))
+	}
+
+	newestFirst := params.Get("sort_order") != "relevancy"
+
+	page := &output.TweetPage{Tweets: []output.Tweet{}}
+	cursor := opts.Cursor
+	skip := 0
+	if id, ok := strings.CutPrefix(cursor, untilCursor); ok {
+		params.Set("until_id", id)
+		cursor = ""
+	} else if rest, ok := strings.CutPrefix(cursor, skipCursor); ok {
+		n, token, _ := strings.Cut(rest, ":")
+		var err error
+		if skip, err = strconv.Atoi(n); err != nil || skip < 0 {
+			return nil, fmt.Errorf("invalid cursor %q", opts.Cursor)
+		}
+		cursor = token
+	}
+
+	// Where the last page fetched starts, to cut it with a skip cursor.
+	var pageToken string
+	var pageStart, pageSkip int
+
+	for {
+		size := count
+		if opts.Limit > 0 {
+			size = min(size, opts.Limit-len(page.Tweets))
+		}
+		params.Set("max_results", strconv.Itoa(min(max(size+skip, minResults), maxPageSize)))
+		if cursor != "" {
+			params.Set(tokenParam, cursor)
+		} else {
+			params.Del(tokenParam)
+		}
+
+		data, err := c.doRequest("GET", endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp tweetsResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse tweets: %w", err)
+		}
+
+		tweets := toTweets(&resp)
+		pageToken, pageStart, pageSkip = cursor, len(page.Tweets), min(skip, len(tweets))
+		page.Tweets = append(page.Tweets, tweets[pageSkip:]...)
+		skip = 0
+		cursor = resp.Meta.NextToken
+		page.NextCursor = cursor
+
+		if cursor == "" {
+			break
+		}
+		if opts.Limit > 0 && len(page.Tweets) >= opts.Limit {
+			break
+		}
+		if opts.Limit <= 0 && !opts.All {
+			break
+		}
+	}
+
+	// The API's minimum page size and the last page can overshoot Limit.
+	if opts.Limit > 0 && len(page.Tweets) > opts.Limit {
+		page.Tweets = page.Tweets[:opts.Limit]
+		if newestFirst {
+			page.NextCursor = untilCursor + page.Tweets[len(page.Tweets)-1].ID
+		} else {
+			kept := pageSkip + opts.Limit - pageStart
+			page.NextCursor = fmt.Sprintf("%s%d:%s", skipCursor, kept, pageToken)
+		}
+	}
+
+	return page, nil
+}
+
+func toTweets(resp *tweetsResponse) []output.Tweet {
+	userMap := make(map[string]string)
+	for _, u := range resp.Includes.Users {
+		userMap[u.ID] = u.Username
+	}
+
+	tweets := make([]output.Tweet, 0, len(resp.Data))
+	for _, t := range resp.Data {
+		createdAt := t.CreatedAt
+		if createdAt == "" {
+			createdAt = time.Now().Format(time.RFC3339)
+		}
+		tweets = append(tweets, output.Tweet{
+			ID:              t.ID,
+			Text:            t.Text,
+			AuthorID:        t.AuthorID,
+			AuthorUsername:  userMap[t.AuthorID],
+			CreatedAt:       createdAt,
+			ConversationID:  t.ConversationID,
+			InReplyTo:       t.inReplyTo(),
+			InReplyToUserID: t.InReplyToUserID,
+			Likes:           t.PublicMetrics.LikeCount,
+			Retweets:        t.PublicMetrics.RetweetCount,
+			Replies:         t.PublicMetrics.ReplyCount,
+		})
+	}
+	return tweets
+}
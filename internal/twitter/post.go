@@ -9,8 +9,15 @@ import (
 )
 
 type createTweetRequest struct {
-	Text  string       `json:"text"`
-	Reply *replyConfig `json:"reply,omitempty"`
+	Text         string       `json:"text"`
+	Reply        *replyConfig `json:"reply,omitempty"`
+	QuoteTweetID string       `json:"quote_tweet_id,omitempty"`
+	Poll         *pollConfig  `json:"poll,omitempty"`
+}
+
+type pollConfig struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
 }
 
 type replyConfig struct {
@@ -24,68 +31,80 @@ type createTweetResponse struct {
 	} `json:"data"`
 }
 
-func (c *Client) PostTweet(text string) (*output.PostResult, error) {
-	body, err := json.Marshal(createTweetRequest{Text: text})
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal tweet: %w", err)
-	}
+// ThreadOptions controls how the first tweet of a thread is attached to an
+// existing tweet, and whether the last tweet carries a poll. The rest of
+// the thread always replies to the tweet before it.
+type ThreadOptions struct {
+	InReplyTo string
+	Quote     string
 
-	data, err := c.doRequest("POST", baseURL+"/tweets", bytes.NewReader(body))
+	PollOptions         []string
+	PollDurationMinutes int
+}
+
+func (c *Client) PostTweet(text string) (*output.PostResult, error) {
+	result, err := c.createTweet(createTweetRequest{Text: text})
 	if err != nil {
 		return nil, fmt.Errorf("failed to post tweet: %w", err)
 	}
-
-	var resp createTweetResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return &output.PostResult{
-		Network: "twitter",
-		ID:      resp.Data.ID,
-		URL:     fmt.Sprintf("https://twitter.com/i/status/%s", resp.Data.ID),
-		Text:    resp.Data.Text,
-	}, nil
+	return result, nil
 }
 
 func (c *Client) PostThread(chunks []string) ([]output.PostResult, error) {
+	return c.PostThreadWith(chunks, ThreadOptions{})
+}
+
+func (c *Client) PostThreadWith(chunks []string, opts ThreadOptions) ([]output.PostResult, error) {
 	var results []output.PostResult
-	var lastID string
+	lastID := opts.InReplyTo
 
 	for i, chunk := range chunks {
-		var body []byte
-		var err error
-
+		req := createTweetRequest{Text: chunk}
+		if lastID != "" {
+			req.Reply = &replyConfig{InReplyToTweetID: lastID}
+		}
 		if i == 0 {
-			body, err = json.Marshal(createTweetRequest{Text: chunk})
-		} else {
-			body, err = json.Marshal(createTweetRequest{
-				Text:  chunk,
-				Reply: &replyConfig{InReplyToTweetID: lastID},
-			})
+			req.QuoteTweetID = opts.Quote
 		}
-		if err != nil {
-			return results, fmt.Errorf("failed to marshal tweet %d: %w", i+1, err)
+		if i == len(chunks)-1 && len(opts.PollOptions) > 0 {
+			req.Poll = &pollConfig{
+				Options:         opts.PollOptions,
+				DurationMinutes: opts.PollDurationMinutes,
+			}
 		}
 
-		data, err := c.doRequest("POST", baseURL+"/tweets", bytes.NewReader(body))
+		result, err := c.createTweet(req)
 		if err != nil {
 			return results, fmt.Errorf("failed to post tweet %d: %w", i+1, err)
 		}
 
-		var resp createTweetResponse
-		if err := json.Unmarshal(data, &resp); err != nil {
-			return results, fmt.Errorf("failed to parse response for tweet %d: %w", i+1, err)
-		}
-
-		lastID = resp.Data.ID
-		results = append(results, output.PostResult{
-			Network: "twitter",
-			ID:      resp.Data.ID,
-			URL:     fmt.Sprintf("https://twitter.com/i/status/%s", resp.Data.ID),
-			Text:    resp.Data.Text,
-		})
+		lastID = result.ID
+		results = append(results, *result)
 	}
 
 	return results, nil
 }
+
+func (c *Client) createTweet(req createTweetRequest) (*output.PostResult, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tweet: %w", err)
+	}
+
+	data, err := c.doRequest("POST", baseURL+"/tweets", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp createTweetResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &output.PostResult{
+		Network: "twitter",
+		ID:      resp.Data.ID,
+		URL:     fmt.Sprintf("https://twitter.com/i/status/%s", resp.Data.ID),
+		Text:    resp.Data.Text,
+	}, nil
+}
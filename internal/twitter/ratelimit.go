@@ -0,0 +1,43 @@
+package twitter
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the rate limit state Twitter reported for the most recent
+// request. Reset is zero if the response carried no rate limit headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitError is returned when a request is rejected with 429.
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "rate limited (429): too many requests, try again later"
+	}
+	return fmt.Sprintf("rate limited (429): too many requests, resets at %s", e.Reset.Local().Format("15:04:05"))
+}
+
+func parseRateLimit(h http.Header) RateLimit {
+	var rl RateLimit
+	rl.Limit, _ = strconv.Atoi(h.Get("x-rate-limit-limit"))
+	rl.Remaining, _ = strconv.Atoi(h.Get("x-rate-limit-remaining"))
+	if reset, err := strconv.ParseInt(h.Get("x-rate-limit-reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl
+}
+
+// RateLimit returns the rate limit state of the last request made.
+func (c *Client) RateLimit() RateLimit {
+	return c.rateLimit
+}
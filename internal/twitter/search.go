@@ -0,0 +1,30 @@
+package twitter
+
+import (
+	"fmt"
+
+	"github.com/hev/socials/internal/output"
+)
+
+// SearchRecent runs a query over the last seven days of tweets. query uses
+// Twitter's full operator syntax; sortOrder is "recency", "relevancy" or
+// empty for the API default.
+func (c *Client) SearchRecent(query, sortOrder string, opts PageOptions) (*output.TweetPage, error) {
+	switch sortOrder {
+	case "", "recency", "relevancy":
+	default:
+		return nil, fmt.Errorf("unknown sort order %q (use recency or relevancy)", sortOrder)
+	}
+
+	params := tweetListParams()
+	params.Set("query", query)
+	if sortOrder != "" {
+		params.Set("sort_order", sortOrder)
+	}
+
+	page, err := c.listTweets(baseURL+"/tweets/search/recent", params, opts, "next_token", 10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tweets: %w", err)
+	}
+	return page, nil
+}
@@ -0,0 +1,112 @@
+package twitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hev/socials/internal/output"
+)
+
+// lookupTweets fetches tweets by ID with the same fields as tweet lists.
+func (c *Client) lookupTweets(ids ...string) ([]output.Tweet, error) {
+	params := tweetListParams()
+	params.Set("ids", strings.Join(ids, ","))
+
+	data, err := c.doRequest("GET", baseURL+"/tweets?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp tweetsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse tweets: %w", err)
+	}
+	return toTweets(&resp), nil
+}
+
+// GetConversation returns every tweet in the conversation containing
+// tweetID as a reply tree, flattened depth first with replies in
+// chronological order. Replies come from recent search, so only the last
+// seven days of a conversation are included; replies whose parent is
+// missing are attached to the root.
+func (c *Client) GetConversation(tweetID string) ([]output.ThreadTweet, error) {
+	found, err := c.lookupTweets(tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up tweet %s: %w", tweetID, err)
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("tweet %s not found", tweetID)
+	}
+
+	rootID := found[0].ConversationID
+	if rootID == "" {
+		rootID = tweetID
+	}
+
+	tweets := map[string]output.Tweet{found[0].ID: found[0]}
+	if _, ok := tweets[rootID]; !ok {
+		root, err := c.lookupTweets(rootID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up conversation root %s: %w", rootID, err)
+		}
+		for _, t := range root {
+			tweets[t.ID] = t
+		}
+	}
+
+	query := "conversation_id:" + rootID
+	page, err := c.SearchRecent(query, "", PageOptions{Count: maxPageSize, All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch conversation: %w", err)
+	}
+	for _, t := range page.Tweets {
+		tweets[t.ID] = t
+	}
+
+	children := map[string][]string{}
+	for id, t := range tweets {
+		if id == rootID {
+			continue
+		}
+		parent := t.InReplyTo
+		if _, ok := tweets[parent]; !ok {
+			parent = rootID
+		}
+		children[parent] = append(children[parent], id)
+	}
+
+	var thread []output.ThreadTweet
+	// ParentID is the tweet each reply is shown under; replies to tweets
+	// the search didn't return hang off the root and are marked orphans,
+	// with the real parent still in InReplyTo.
+	var walk func(id, parent string, depth int)
+	walk = func(id, parent string, depth int) {
+		t, ok := tweets[id]
+		if ok {
+			thread = append(thread, output.ThreadTweet{
+				Tweet:    t,
+				ParentID: parent,
+				Depth:    depth,
+				Orphan:   parent != "" && t.InReplyTo != parent,
+			})
+		}
+		replies := children[id]
+		slices.SortFunc(replies, func(a, b string) int {
+			switch {
+			case lessID(a, b):
+				return -1
+			case lessID(b, a):
+				return 1
+			}
+			return 0
+		})
+		for _, reply := range replies {
+			walk(reply, id, depth+1)
+		}
+	}
+	walk(rootID, "", 0)
+
+	return thread, nil
+}
@@ -0,0 +1,122 @@
+package twitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hev/socials/internal/output"
+)
+
+type userData struct {
+	ID              string `json:"id"`
+	Username        string `json:"username"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Location        string `json:"location"`
+	URL             string `json:"url"`
+	ProfileImageURL string `json:"profile_image_url"`
+	CreatedAt       string `json:"created_at"`
+	Verified        bool   `json:"verified"`
+	VerifiedType    string `json:"verified_type"`
+	Protected       bool   `json:"protected"`
+	PublicMetrics   struct {
+		FollowersCount int `json:"followers_count"`
+		FollowingCount int `json:"following_count"`
+		TweetCount     int `json:"tweet_count"`
+		ListedCount    int `json:"listed_count"`
+	} `json:"public_metrics"`
+}
+
+type userResponse struct {
+	Data userData `json:"data"`
+}
+
+const userFields = "created_at,description,location,url,profile_image_url,public_metrics,verified,verified_type,protected"
+
+// GetUser looks up a profile by "@handle", handle or numeric user ID.
+func (c *Client) GetUser(user string) (*output.TwitterUser, error) {
+	var endpoint string
+	if username, ok := parseUsername(user); ok {
+		endpoint = fmt.Sprintf("%s/users/by/username/%s", baseURL, url.PathEscape(username))
+	} else {
+		endpoint = fmt.Sprintf("%s/users/%s", baseURL, user)
+	}
+	return c.lookupUser(endpoint, user)
+}
+
+// GetMe returns the profile of the account the client's tokens belong to.
+func (c *Client) GetMe() (*output.TwitterUser, error) {
+	return c.lookupUser(baseURL+"/users/me", "me")
+}
+
+func (c *Client) lookupUser(endpoint, user string) (*output.TwitterUser, error) {
+	data, err := c.doRequest("GET", endpoint+"?user.fields="+userFields, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user %s: %w", user, err)
+	}
+
+	var resp userResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse user: %w", err)
+	}
+	if resp.Data.ID == "" {
+		return nil, fmt.Errorf("user %s not found", user)
+	}
+
+	u := resp.Data
+	return &output.TwitterUser{
+		ID:              u.ID,
+		Username:        u.Username,
+		Name:            u.Name,
+		Description:     u.Description,
+		Location:        u.Location,
+		URL:             u.URL,
+		ProfileImageURL: u.ProfileImageURL,
+		CreatedAt:       u.CreatedAt,
+		Verified:        u.Verified,
+		VerifiedType:    u.VerifiedType,
+		Protected:       u.Protected,
+		Followers:       u.PublicMetrics.FollowersCount,
+		Following:       u.PublicMetrics.FollowingCount,
+		Tweets:          u.PublicMetrics.TweetCount,
+		Listed:          u.PublicMetrics.ListedCount,
+	}, nil
+}
+
+// ResolveUserID turns "@handle", a handle or a numeric ID into a user ID,
+// only calling the API for handles.
+func (c *Client) ResolveUserID(user string) (string, error) {
+	if _, ok := parseUsername(user); !ok {
+		return user, nil
+	}
+	u, err := c.GetUser(user)
+	if err != nil {
+		return "", err
+	}
+	return u.ID, nil
+}
+
+// GetUserTweets returns a user's own tweets, newest first.
+func (c *Client) GetUserTweets(userID string, opts PageOptions) (*output.TweetPage, error) {
+	endpoint := fmt.Sprintf("%s/users/%s/tweets", baseURL, userID)
+
+	page, err := c.listTweets(endpoint, tweetListParams(), opts, "pagination_token", 5)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tweets for user %s: %w", userID, err)
+	}
+	return page, nil
+}
+
+// parseUsername reports whether user is a handle rather than a numeric ID.
+// A leading "@" always means a handle, since handles can be all digits.
+func parseUsername(user string) (string, bool) {
+	if strings.HasPrefix(user, "@") {
+		return strings.TrimPrefix(user, "@"), true
+	}
+	if user != "" && strings.Trim(user, "0123456789") == "" {
+		return "", false
+	}
+	return user, true
+}